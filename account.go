@@ -28,20 +28,51 @@ func (ag *AccountService) Settings() (settings *AccountSettings, err error) {
 // user object if they are.
 // See https://dev.twitter.com/docs/api/1.1/get/account/verify_credentials
 func (ag *AccountService) VerifyCredentials(opts *Optionals) (user *User, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+	opts = opts.Clone()
 	user = &User{}
 	err = ag.Call("GET", "account/verify_credentials", opts, user)
 	return
 }
 
+// Options of UpdateSettings. Only the fields that are set are updated.
+type SettingsOptions struct {
+	// Where On Earth ID of the location trends are shown for
+	TrendLocationWoeid int64
+	// Whether notifications are paused during sleep time
+	SleepTimeEnabled *bool
+	// Hours sleep time starts and ends at, in the user's time zone
+	StartSleepTime, EndSleepTime TimeOfDay
+	// Rails name of the user's time zone, e.g. "Europe/Copenhagen"
+	TimeZone string
+	// Language of the user interface
+	Lang string
+}
+
+// Returns the optionals to pass to UpdateSettings
+func (so *SettingsOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if so == nil {
+		return opts
+	}
+	if so.TrendLocationWoeid != 0 {
+		opts.Set("trend_location_woeid", so.TrendLocationWoeid)
+	}
+	opts.setBoolPtr("sleep_time_enabled", so.SleepTimeEnabled)
+	if so.StartSleepTime.Valid {
+		opts.Set("start_sleep_time", so.StartSleepTime)
+	}
+	if so.EndSleepTime.Valid {
+		opts.Set("end_sleep_time", so.EndSleepTime)
+	}
+	opts.setString("time_zone", so.TimeZone)
+	opts.setString("lang", so.Lang)
+	return opts
+}
+
 // Update authenticating user's settings.
 // See https://dev.twitter.com/docs/api/1.1/post/account/settings
 func (ag *AccountService) UpdateSettings(opts *Optionals) (newSettings *AccountSettings, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+	opts = opts.Clone()
 	newSettings = &AccountSettings{}
 	err = ag.Call("POST", "account/settings", opts, newSettings)
 	return
@@ -52,21 +83,49 @@ func (ag *AccountService) UpdateSettings(opts *Optionals) (newSettings *AccountS
 func (ag *AccountService) EnableSMS(enable bool) (err error) {
 	opts := NewOptionals()
 	if enable {
-		opts.Add("device", "sms")
+		opts.Set("device", "sms")
 	} else {
-		opts.Add("device", "none")
+		opts.Set("device", "none")
 	}
 	err = ag.Call("POST", "account/update_delivery_device", opts, nil)
 	return
 }
 
+// Options of UpdateProfile. Only the fields that are set are updated.
+type ProfileOptions struct {
+	Name        string
+	Url         string
+	Location    string
+	Description string
+	// Color of links, as a hexadecimal value without "#"
+	ProfileLinkColor string
+	// Whether to include entities in the returned user
+	IncludeEntities *bool
+	// Leave the last tweet of the user out of the returned user
+	SkipStatus bool
+}
+
+// Returns the optionals to pass to UpdateProfile
+func (po *ProfileOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if po == nil {
+		return opts
+	}
+	opts.setString("name", po.Name)
+	opts.setString("url", po.Url)
+	opts.setString("location", po.Location)
+	opts.setString("description", po.Description)
+	opts.setString("profile_link_color", po.ProfileLinkColor)
+	opts.setBoolPtr("include_entities", po.IncludeEntities)
+	opts.setBool("skip_status", po.SkipStatus)
+	return opts
+}
+
 // Sets values that users are able to set under the "Account" tab of their
 // settings page. Only the parameters specified will be updated.
 // See https://dev.twitter.com/docs/api/1.1/post/account/update_profile
 func (ag *AccountService) UpdateProfile(opts *Optionals) (user *User, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+	opts = opts.Clone()
 	user = &User{}
 	err = ag.Call("POST", "account/update_profile", opts, user)
 	return
@@ -77,14 +136,12 @@ func (ag *AccountService) UpdateProfile(opts *Optionals) (user *User, err error)
 // background image.
 // https://dev.twitter.com/docs/api/1.1/post/account/update_profile_background_image
 func (ag *AccountService) UpdateProfileBackgroundImage(image []byte, opts *Optionals) (user *User, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+	opts = opts.Clone()
 	if len(image) > 0 {
-		opts.Add("image", base64.StdEncoding.EncodeToString(image))
-		opts.Add("use", true)
+		opts.Set("image", base64.StdEncoding.EncodeToString(image))
+		opts.Set("use", true)
 	} else {
-		opts.Add("use", false)
+		opts.Set("use", false)
 	}
 	user = &User{}
 	err = ag.Call("POST", "account/update_profile_background_image", opts, user)
@@ -92,14 +149,36 @@ func (ag *AccountService) UpdateProfileBackgroundImage(image []byte, opts *Optio
 
 }
 
+// Options of UpdateProfileColors. Colors are hexadecimal values without
+// "#", and only the ones that are set are updated.
+type ProfileColorsOptions struct {
+	ProfileBackgroundColor    string
+	ProfileLinkColor          string
+	ProfileSidebarBorderColor string
+	ProfileSidebarFillColor   string
+	ProfileTextColor          string
+}
+
+// Returns the optionals to pass to UpdateProfileColors
+func (co *ProfileColorsOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if co == nil {
+		return opts
+	}
+	opts.setString("profile_background_color", co.ProfileBackgroundColor)
+	opts.setString("profile_link_color", co.ProfileLinkColor)
+	opts.setString("profile_sidebar_border_color", co.ProfileSidebarBorderColor)
+	opts.setString("profile_sidebar_fill_color", co.ProfileSidebarFillColor)
+	opts.setString("profile_text_color", co.ProfileTextColor)
+	return opts
+}
+
 // Sets one or more hex values that control the color scheme of the
 // authenticating user's profile page on twitter.com. Each parameter's value
 // must be a valid hexidecimal value, and may be either three or six characters
 // (ex: #fff or #ffffff).
 func (ag *AccountService) UpdateProfileColors(opts *Optionals) (user *User, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+	opts = opts.Clone()
 	user = &User{}
 	err = ag.Call("POST", "account/update_profile_colors", opts, user)
 	return
@@ -109,10 +188,8 @@ func (ag *AccountService) UpdateProfileColors(opts *Optionals) (user *User, err
 // be the raw data from the image file, not a path or URL
 // See https://dev.twitter.com/docs/api/1.1/post/account/update_profile_image
 func (ag *AccountService) UpdateProfileImage(image []byte, opts *Optionals) (user *User, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	opts.Add("image", base64.StdEncoding.EncodeToString(image))
+	opts = opts.Clone()
+	opts.Set("image", base64.StdEncoding.EncodeToString(image))
 	user = &User{}
 	err = ag.Call("POST", "account/update_profile_image", opts, user)
 	return
@@ -128,56 +205,15 @@ type AccountSettings struct {
 	ScreenName          string `json:"screen_name"`
 	ShowAllInlineMedia  bool   `json:"show_all_inline_media"`
 	SleepTime           struct {
-		Enabled   bool `json:"enabled"`
-		EndTime   int
-		StartTime int
+		Enabled   bool      `json:"enabled"`
+		EndTime   TimeOfDay `json:"end_time"`
+		StartTime TimeOfDay `json:"start_time"`
 	} `json:"sleep_time"`
 	TimeZone struct {
 		Name       string `json:"name"`
 		TzinfoName string `json:"tzinfo_name"`
-		UtcOffset  int64
+		UtcOffset  int64  `json:"utc_offset"`
 	} `json:"time_zone"`
-	TrendLocation []struct {
-		Country     string `json:"country"`
-		CountryCode string `json:"countryCode"`
-		Name        string `json:"name"`
-		ParentId    int64  `json:"parentid"`
-		PlaceType   struct {
-			Code int64  `json:"code"`
-			Name string `json:"name"`
-		}
-		Url   string `json:"url"`
-		WoeId int64  `json:"woeid"`
-	} `json:"trend_location"`
-	UseCookiePersonalization bool `json:"use_cookie_personalization"`
-}
-
-// Represents Twitter's current resource limits
-// See https://dev.twitter.com/docs/rate-limiting/1.1/limits
-// Usage:
-//	limits, _ := c.Help.Limits()
-//	fmt.Printf("App has %d user_timeline calls remaining\n",
-//		limits["statuses"]["/statuses/user_timeline"].Remaining)
-type Limits struct {
-	// For which context these limits are
-	// For tweetlib this will always be the user token
-	Context struct {
-		AccessToken string `json:"access_token"`
-	} `json:"rate_limit_context"`
-
-	// Resrouce families are "accounts", "help", etc
-	ResourceFamilies map[string]map[string]struct {
-		// How many calls remaining for this resource
-		Remaining int `json:"remaining"`
-		// When the limit will reset (epoch time)
-		Reset int64 `json:"reset"`
-		// Total number of calls allowed
-		Limit int `json:"limit"`
-	} `json:"resources"`
-}
-
-type LimitResourceFamily map[string]struct {
-	Remaining int   `json:"remaining"`
-	Reset     int64 `json:"reset"`
-	Limit     int   `json:"limit"`
+	TrendLocation            TrendLocationList `json:"trend_location"`
+	UseCookiePersonalization bool              `json:"use_cookie_personalization"`
 }
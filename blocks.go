@@ -0,0 +1,107 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+// Groups functions to block users
+type BlocksService struct {
+	*Client
+}
+
+// Returns a cursored collection of the users blocked by the authenticating
+// user. See UserCursorOptions.
+// See https://dev.twitter.com/docs/api/1.1/get/blocks/list
+func (bs *BlocksService) List(cursor int64, opts *Optionals) (users *UserCursor, err error) {
+	users = &UserCursor{}
+	err = bs.userCursor("blocks/list", "", 0, cursor, opts, users)
+	return
+}
+
+// Calls fn with every page of the users blocked by the authenticating user,
+// until all of them have been returned or fn returns an error. Return
+// ErrStopPaging from fn to stop paging early.
+func (bs *BlocksService) ListPages(opts *Optionals, fn func([]User) error) error {
+	return bs.usersPages("blocks/list", "", 0, opts, fn)
+}
+
+// Returns a cursored collection of the ids of the users blocked by the
+// authenticating user
+// See https://dev.twitter.com/docs/api/1.1/get/blocks/ids
+func (bs *BlocksService) IDs(cursor int64, opts *Optionals) (IDs *Cursor, err error) {
+	IDs = &Cursor{}
+	err = bs.userCursor("blocks/ids", "", 0, cursor, opts, IDs)
+	return
+}
+
+// Calls fn with every page of the ids of the users blocked by the
+// authenticating user. See ListPages.
+func (bs *BlocksService) IDsPages(opts *Optionals, fn func([]ID) error) error {
+	return bs.idsPages("blocks/ids", "", 0, opts, fn)
+}
+
+// Blocks the user specified by screen name or by id as the authenticating
+// user, which also unfollows them. Returns the blocked user.
+// See https://dev.twitter.com/docs/api/1.1/post/blocks/create
+func (bs *BlocksService) Create(screenName string, userID ID, opts *Optionals) (user *User, err error) {
+	return bs.userAction("blocks/create", screenName, userID, opts)
+}
+
+// Unblocks the user specified by screen name or by id as the
+// authenticating user. Returns the unblocked user.
+// See https://dev.twitter.com/docs/api/1.1/post/blocks/destroy
+func (bs *BlocksService) Destroy(screenName string, userID ID, opts *Optionals) (user *User, err error) {
+	return bs.userAction("blocks/destroy", screenName, userID, opts)
+}
+
+// Groups functions to mute users
+type MutesService struct {
+	*Client
+}
+
+// Returns a cursored collection of the users muted by the authenticating
+// user. See UserCursorOptions.
+// See https://dev.twitter.com/docs/api/1.1/get/mutes/users/list
+func (ms *MutesService) List(cursor int64, opts *Optionals) (users *UserCursor, err error) {
+	users = &UserCursor{}
+	err = ms.userCursor("mutes/users/list", "", 0, cursor, opts, users)
+	return
+}
+
+// Calls fn with every page of the users muted by the authenticating user,
+// until all of them have been returned or fn returns an error. Return
+// ErrStopPaging from fn to stop paging early.
+func (ms *MutesService) ListPages(opts *Optionals, fn func([]User) error) error {
+	return ms.usersPages("mutes/users/list", "", 0, opts, fn)
+}
+
+// Returns a cursored collection of the ids of the users muted by the
+// authenticating user
+// See https://dev.twitter.com/docs/api/1.1/get/mutes/users/ids
+func (ms *MutesService) IDs(cursor int64, opts *Optionals) (IDs *Cursor, err error) {
+	IDs = &Cursor{}
+	err = ms.userCursor("mutes/users/ids", "", 0, cursor, opts, IDs)
+	return
+}
+
+// Calls fn with every page of the ids of the users muted by the
+// authenticating user. See ListPages.
+func (ms *MutesService) IDsPages(opts *Optionals, fn func([]ID) error) error {
+	return ms.idsPages("mutes/users/ids", "", 0, opts, fn)
+}
+
+// Mutes the user specified by screen name or by id as the authenticating
+// user. Returns the muted user.
+// See https://dev.twitter.com/docs/api/1.1/post/mutes/users/create
+func (ms *MutesService) Create(screenName string, userID ID, opts *Optionals) (user *User, err error) {
+	return ms.userAction("mutes/users/create", screenName, userID, opts)
+}
+
+// Unmutes the user specified by screen name or by id as the authenticating
+// user. Returns the unmuted user.
+// See https://dev.twitter.com/docs/api/1.1/post/mutes/users/destroy
+func (ms *MutesService) Destroy(screenName string, userID ID, opts *Optionals) (user *User, err error) {
+	return ms.userAction("mutes/users/destroy", screenName, userID, opts)
+}
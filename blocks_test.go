@@ -0,0 +1,110 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestUserActions(t *testing.T) {
+	var method, path string
+	var form url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		method, path, form = r.Method, r.URL.Path, r.PostForm
+		w.Write([]byte(`{"id": 783214, "screen_name": "twitter"}`))
+	})
+	tests := []struct {
+		call func() (*User, error)
+		path string
+		form string
+	}{
+		{func() (*User, error) { return c.Blocks.Create("twitter", 0, nil) }, "/blocks/create.json", "screen_name=twitter"},
+		{func() (*User, error) { return c.Blocks.Destroy("", 783214, nil) }, "/blocks/destroy.json", "user_id=783214"},
+		{func() (*User, error) { return c.Mutes.Create("", 783214, nil) }, "/mutes/users/create.json", "user_id=783214"},
+		{func() (*User, error) { return c.Mutes.Destroy("twitter", 783214, nil) }, "/mutes/users/destroy.json", "screen_name=twitter"},
+		{
+			func() (*User, error) {
+				opts := NewOptionals()
+				opts.Set("perform_block", false)
+				return c.User.ReportSpam("", 783214, opts)
+			},
+			"/users/report_spam.json", "perform_block=false&user_id=783214",
+		},
+		{func() (*User, error) { return c.Friendships.Create("twitter", 0, nil) }, "/friendships/create.json", "screen_name=twitter"},
+		{func() (*User, error) { return c.Friendships.Destroy("", 783214, nil) }, "/friendships/destroy.json", "user_id=783214"},
+	}
+	for _, tt := range tests {
+		user, err := tt.call()
+		if err != nil {
+			t.Errorf("%s: %v", tt.path, err)
+			continue
+		}
+		if method != "POST" || path != tt.path || form.Encode() != tt.form {
+			t.Errorf("%s: got %s %s with %s, want %s", tt.path, method, path, form.Encode(), tt.form)
+		}
+		if user.Id != 783214 {
+			t.Errorf("%s: user = %+v", tt.path, user)
+		}
+	}
+}
+
+func TestBlocksPages(t *testing.T) {
+	for _, tt := range []struct {
+		path, key string
+		pages     []string
+		run       func(c *Client, got *[]ID) error
+	}{
+		{"/blocks/ids.json", "ids", []string{`[1, 2]`, `[3]`}, func(c *Client, got *[]ID) error {
+			return c.Blocks.IDsPages(nil, func(ids []ID) error {
+				*got = append(*got, ids...)
+				return nil
+			})
+		}},
+		{"/blocks/list.json", "users", []string{`[{"id": 1}, {"id": 2}]`, `[{"id": 3}]`}, func(c *Client, got *[]ID) error {
+			return c.Blocks.ListPages(nil, func(users []User) error {
+				for _, u := range users {
+					*got = append(*got, u.Id)
+				}
+				return nil
+			})
+		}},
+		{"/mutes/users/ids.json", "ids", []string{`[1, 2]`, `[3]`}, func(c *Client, got *[]ID) error {
+			return c.Mutes.IDsPages(nil, func(ids []ID) error {
+				*got = append(*got, ids...)
+				return nil
+			})
+		}},
+		{"/mutes/users/list.json", "users", []string{`[{"id": 1}, {"id": 2}]`, `[{"id": 3}]`}, func(c *Client, got *[]ID) error {
+			return c.Mutes.ListPages(nil, func(users []User) error {
+				for _, u := range users {
+					*got = append(*got, u.Id)
+				}
+				return nil
+			})
+		}},
+	} {
+		var queries []url.Values
+		c := cursorServer(t, tt.path, tt.key, tt.pages, &queries)
+		var got []ID
+		if err := tt.run(c, &got); err != nil {
+			t.Errorf("%s: %v", tt.path, err)
+		}
+		if fmt.Sprint(got) != "[1 2 3]" || fmt.Sprint(cursors(queries)) != "[-1 1]" {
+			t.Errorf("%s: got %v with cursors %v", tt.path, got, cursors(queries))
+		}
+		// the authenticating user is implied
+		for _, q := range queries {
+			if q.Has("user_id") || q.Has("screen_name") {
+				t.Errorf("%s: query %v names a user", tt.path, q)
+			}
+		}
+	}
+}
@@ -13,11 +13,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"reflect"
+	"net/url"
 )
 
-var _ = reflect.TypeOf
-
 const (
 	// URL to post tweets
 	postURL = "http://api.twitter.com/1.1/statuses/update.json"
@@ -74,15 +72,63 @@ type Client struct {
 	// Followers services
 	Followers *FollowersService
 
+	// Favorites (likes) services
+	Favorites *FavoritesService
+
+	// Friendships services
+	Friendships *FriendshipsService
+
+	// Blocks services
+	Blocks *BlocksService
+
+	// Mutes services
+	Mutes *MutesService
+
+	// Saved searches services
+	SavedSearches *SavedSearchesService
+
+	// Trends services
+	Trends *TrendsService
+
+	// Geo services
+	Geo *GeoService
+
+	// Media upload services
+	Media *MediaService
+
 	// API base endpoint. This is the base endpoing URL for API calls. This
 	// can be overwritten by an application that needs to use a different
 	// version of the library or maybe a mock.
 	Endpoint string
 
+	// Base endpoint media is uploaded to. Unlike the other calls, uploads
+	// are not made against api.twitter.com. Like Endpoint, it can be
+	// overwritten, e.g. to use a mock.
+	UploadEndpoint string
+
 	// The token for twitter application we are using. If it is set to "" then
 	// client will assume that we are not making application-only API calls and
 	// are instead making calls using user authenticated APIs
 	ApplicationToken string
+
+	// When set, tweets are requested in extended mode (tweet_mode=extended)
+	// and hold their complete text in FullText rather than a Text truncated
+	// to 140 characters. See Tweet.DisplayText.
+	ExtendedTweets bool
+
+	// When set, calls whose response does not match the types it is decoded
+	// into fail with a *DecodeError listing the offending fields. Otherwise
+	// those fields are left empty and the rest of the response is returned.
+	StrictDecoding bool
+
+	// If not nil, it is called with the details of every response that does
+	// not match the types it is decoded into, whether StrictDecoding is set
+	// or not. This is useful to learn that Twitter changed a field's type.
+	DecodeErrorHandler func(*DecodeError)
+
+	// When set, the model values returned by calls (Tweet, User, etc) keep
+	// the exact JSON they were decoded from in their Raw field.
+	KeepRawJSON bool
 }
 
 // Creates a new twitter client for user authenticated API calls
@@ -115,7 +161,16 @@ func constructClient(httpClient *http.Client, bearerToken string) *Client {
 	c.Lists = &ListService{c}
 	c.Friends = &FriendsService{c}
 	c.Followers = &FollowersService{c}
+	c.Favorites = &FavoritesService{c}
+	c.Friendships = &FriendshipsService{c}
+	c.Blocks = &BlocksService{c}
+	c.Mutes = &MutesService{c}
+	c.SavedSearches = &SavedSearchesService{c}
+	c.Trends = &TrendsService{c}
+	c.Geo = &GeoService{c}
+	c.Media = &MediaService{c}
 	c.Endpoint = "https://api.twitter.com/1.1"
+	c.UploadEndpoint = "https://upload.twitter.com/1.1"
 	c.ApplicationToken = bearerToken
 	return c
 }
@@ -140,14 +195,13 @@ func (c *Client) CallJSON(method, endpoint string, opts *Optionals) (rawJSON []b
 		err = fmt.Errorf("Invalid method '%s'. Must be either GET or POST.", method)
 		return
 	}
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	endpoint = fmt.Sprintf("%s/%s.json?%s", apiURL, endpoint, opts.Values.Encode())
+	opts = opts.Clone()
+	values := c.params(opts)
+	endpoint = fmt.Sprintf("%s/%s.json?%s", c.Endpoint, endpoint, values.Encode())
 	fmt.Println(endpoint)
 	var req *http.Request
 	if method == "POST" {
-		body := bytes.NewBuffer([]byte(opts.Values.Encode()))
+		body := bytes.NewBuffer([]byte(values.Encode()))
 		req, _ = http.NewRequest(method, endpoint, body)
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	} else {
@@ -168,9 +222,25 @@ func (c *Client) CallJSON(method, endpoint string, opts *Optionals) (rawJSON []b
 	return
 }
 
+// Returns the parameters to send along with a request: the given optionals
+// plus those implied by the client's settings.
+func (c *Client) params(opts *Optionals) url.Values {
+	if !c.ExtendedTweets || opts.Values.Get("tweet_mode") != "" {
+		return opts.Values
+	}
+	values := make(url.Values, len(opts.Values)+1)
+	for k, v := range opts.Values {
+		values[k] = v
+	}
+	values.Set("tweet_mode", "extended")
+	return values
+}
+
 // Performs an arbitrary API call and tries to unmarshal the result into
 // 'resp' on success. This is generally used internally by the other functions
-// but it could be used to perform unsupported API calls.
+// but it could be used to perform unsupported API calls. Values that do not
+// match the type of the field they are decoded into are dealt with according
+// to StrictDecoding and DecodeErrorHandler.
 //
 // Example usage:
 //
@@ -189,10 +259,7 @@ func (c *Client) Call(method, endpoint string, opts *Optionals, resp interface{}
 	}
 	fmt.Printf("Response: %s\n", rawJSON)
 	if resp != nil {
-		if err = json.Unmarshal(rawJSON, resp); err != nil &&
-			reflect.TypeOf(err) != reflect.TypeOf(&json.UnmarshalTypeError{}) {
-			return err
-		}
+		return c.decode(endpoint, rawJSON, resp)
 	}
 	return nil
 }
@@ -0,0 +1,68 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Returns a client whose API calls and uploads are served by handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c, err := New(srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Endpoint = srv.URL
+	c.UploadEndpoint = srv.URL
+	return c
+}
+
+func TestEndpoint(t *testing.T) {
+	var method, path, query string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path, query = r.Method, r.URL.Path, r.URL.RawQuery
+		w.Write([]byte(`{"id": 20, "text": "just setting up my twttr"}`))
+	})
+	tweet, err := c.Tweets.Get(20, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != "GET" || path != "/statuses/show.json" || query != "id=20" {
+		t.Errorf("requested %s %s?%s", method, path, query)
+	}
+	if tweet.Id != 20 {
+		t.Errorf("tweet id = %d", tweet.Id)
+	}
+}
+
+func TestUpdateWithMediaEndpoint(t *testing.T) {
+	var path, status, file string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path, status = r.URL.Path, r.FormValue("status")
+		if f, _, err := r.FormFile("media[]"); err == nil {
+			b, _ := io.ReadAll(f)
+			file = string(b)
+		}
+		w.Write([]byte(`{"id": 21, "text": "hello"}`))
+	})
+	media := &TweetMedia{Filename: "hello.png", Data: []byte("png data")}
+	tweet, err := c.Tweets.UpdateWithMedia("hello", media, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "/statuses/update_with_media.json" || status != "hello" || file != "png data" {
+		t.Errorf("requested %s with status %q and media %q", path, status, file)
+	}
+	if tweet.Id != 21 {
+		t.Errorf("tweet id = %d", tweet.Id)
+	}
+}
@@ -0,0 +1,231 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Decodes the response of a call to endpoint into resp. Fields whose JSON
+// value does not match their Go type are reported to the client's
+// DecodeErrorHandler and, if StrictDecoding is set, make decode fail.
+func (c *Client) decode(endpoint string, rawJSON []byte, resp interface{}) error {
+	err := json.Unmarshal(rawJSON, resp)
+	if err == nil && c.KeepRawJSON {
+		retainRaw(rawJSON, reflect.ValueOf(resp))
+	}
+	var typeErr *json.UnmarshalTypeError
+	if err == nil || !errors.As(err, &typeErr) {
+		return err
+	}
+	derr := &DecodeError{
+		Endpoint: endpoint,
+		Fields:   typeErrors(rawJSON, reflect.TypeOf(resp), ""),
+	}
+	if len(derr.Fields) == 0 {
+		// should not happen, but at least report what json found
+		derr.Fields = append(derr.Fields, typeErr)
+	}
+	if c.DecodeErrorHandler != nil {
+		c.DecodeErrorHandler(derr)
+	}
+	if c.StrictDecoding {
+		return derr
+	}
+	if c.KeepRawJSON {
+		retainRaw(rawJSON, reflect.ValueOf(resp))
+	}
+	return nil
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// Walks data alongside the Go type t it is decoded into and returns an
+// error for every value that does not fit its type. path is the location
+// of data within the response, as reported in the errors' Field.
+func typeErrors(data []byte, t reflect.Type, path string) (errs []*json.UnmarshalTypeError) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	mismatch := func() []*json.UnmarshalTypeError {
+		return []*json.UnmarshalTypeError{{Value: jsonKind(data), Type: t, Field: path}}
+	}
+	// model types decode themselves only to keep their unmodelled fields,
+	// walk them like any other struct
+	pt := reflect.PtrTo(t)
+	if pt.Implements(unmarshalerType) && !pt.Implements(rawHolderType) {
+		err := json.Unmarshal(data, reflect.New(t).Interface())
+		if err != nil {
+			return mismatch()
+		}
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			return mismatch()
+		}
+		fields := jsonFields(t)
+		for _, name := range sortedKeys(obj) {
+			if f, ok := fields.lookup(name); ok {
+				errs = append(errs, typeErrors(obj[name], f.typ, joinPath(path, name))...)
+			}
+		}
+		return errs
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			break // []byte is decoded from a base64 string
+		}
+		var elems []json.RawMessage
+		if json.Unmarshal(data, &elems) != nil {
+			return mismatch()
+		}
+		for i, elem := range elems {
+			errs = append(errs, typeErrors(elem, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return errs
+	case reflect.Map:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			return mismatch()
+		}
+		for _, name := range sortedKeys(obj) {
+			errs = append(errs, typeErrors(obj[name], t.Elem(), joinPath(path, name))...)
+		}
+		return errs
+	case reflect.Interface:
+		return nil
+	}
+	if err := json.Unmarshal(data, reflect.New(t).Interface()); err != nil {
+		return mismatch()
+	}
+	return errs
+}
+
+// Describes the kind of a JSON value the way json.UnmarshalTypeError does
+func jsonKind(data []byte) string {
+	switch data[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "bool"
+	}
+	return "number " + string(data)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func sortedKeys(obj map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// A struct field as seen by encoding/json
+type jsonField struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+// The fields of a struct type keyed by their JSON name
+type jsonFieldSet map[string]*jsonField
+
+// Finds the field a JSON key is decoded into, preferring an exact match
+// but falling back to a case-insensitive one like encoding/json does.
+func (fs jsonFieldSet) lookup(name string) (*jsonField, bool) {
+	if f, ok := fs[name]; ok {
+		return f, true
+	}
+	for k, f := range fs {
+		if strings.EqualFold(k, name) {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+var jsonFieldCache sync.Map // map[reflect.Type]jsonFieldSet
+
+// Returns the fields of struct type t keyed by their JSON name, including
+// those promoted from embedded structs.
+func jsonFields(t reflect.Type) jsonFieldSet {
+	if fs, ok := jsonFieldCache.Load(t); ok {
+		return fs.(jsonFieldSet)
+	}
+	fs := make(jsonFieldSet)
+	collectJSONFields(t, nil, fs)
+	jsonFieldCache.Store(t, fs)
+	return fs
+}
+
+func collectJSONFields(t reflect.Type, index []int, fs jsonFieldSet) {
+	var embedded []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, sf)
+				continue
+			}
+		}
+		if sf.PkgPath != "" {
+			continue // unexported
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fs[name] = &jsonField{name, append(append([]int(nil), index...), i), sf.Type}
+	}
+	// fields of embedded structs are shadowed by those of the outer one
+	for _, sf := range embedded {
+		inner := make(jsonFieldSet)
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		collectJSONFields(ft, append(append([]int(nil), index...), sf.Index...), inner)
+		for name, f := range inner {
+			if _, ok := fs[name]; !ok {
+				fs[name] = f
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"reflect"
+	"testing"
+)
+
+// A tweet whose id, user's follower count and second hashtag's indices do
+// not have the type they are decoded into
+const mismatchedTweet = `{
+	"id": "not a number",
+	"text": "Hello #go #golang",
+	"user": {"screen_name": "gopher", "followers_count": "many"},
+	"entities": {"hashtags": [
+		{"text": "go", "indices": [6, 9]},
+		{"text": "golang", "indices": "10-17"}
+	]}
+}`
+
+func TestDecodeErrors(t *testing.T) {
+	wantFields := []string{"entities.hashtags[1].indices", "id", "user.followers_count"}
+
+	for _, strict := range []bool{false, true} {
+		var handled []*DecodeError
+		c := &Client{
+			StrictDecoding:     strict,
+			DecodeErrorHandler: func(err *DecodeError) { handled = append(handled, err) },
+		}
+		var tweet Tweet
+		err := c.decode("statuses/show", []byte(mismatchedTweet), &tweet)
+
+		if len(handled) != 1 {
+			t.Fatalf("strict=%v: DecodeErrorHandler called %d times, want 1", strict, len(handled))
+		}
+		derr := handled[0]
+		if derr.Endpoint != "statuses/show" {
+			t.Errorf("strict=%v: Endpoint = %q", strict, derr.Endpoint)
+		}
+		var fields []string
+		for _, f := range derr.Fields {
+			fields = append(fields, f.Field)
+		}
+		if !reflect.DeepEqual(fields, wantFields) {
+			t.Errorf("strict=%v: Fields = %v, want %v", strict, fields, wantFields)
+		}
+		if typ := derr.Fields[2].Type; typ != reflect.TypeOf(int64(0)) {
+			t.Errorf("strict=%v: followers_count type = %v, want int64", strict, typ)
+		}
+
+		if strict {
+			if err != derr {
+				t.Errorf("strict: decode returned %v, want the DecodeError", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("lenient: decode returned %v, want nil", err)
+		}
+		// the rest of the response is decoded
+		if tweet.Text != "Hello #go #golang" || tweet.User == nil || tweet.User.ScreenName != "gopher" {
+			t.Errorf("lenient: tweet not decoded: %+v", tweet)
+		}
+		if h := tweet.Entities.Hashtags; len(h) != 2 || h[0].Indices != (Indices{6, 9}) {
+			t.Errorf("lenient: hashtags = %+v", h)
+		}
+	}
+}
+
+func TestDecodeWithoutErrors(t *testing.T) {
+	called := false
+	c := &Client{
+		StrictDecoding:     true,
+		DecodeErrorHandler: func(*DecodeError) { called = true },
+	}
+	var tweet Tweet
+	if err := c.decode("statuses/show", []byte(`{"id": 1, "text": "hi"}`), &tweet); err != nil {
+		t.Fatal(err)
+	}
+	if called {
+		t.Error("DecodeErrorHandler called for a valid response")
+	}
+	// syntax errors are not decode errors
+	if err := c.decode("statuses/show", []byte(`{"id":`), &tweet); err == nil || called {
+		t.Errorf("decode of truncated JSON = %v, handler called = %v", err, called)
+	}
+}
+
+func TestDecodeErrorMessage(t *testing.T) {
+	c := &Client{StrictDecoding: true}
+	var users []User
+	err := c.decode("users/lookup", []byte(`[{"id": 1}, {"id": 2, "verified": "yes"}]`), &users)
+	want := "cannot decode response of users/lookup: [1].verified (string into bool)"
+	if err == nil || err.Error() != want {
+		t.Errorf("error = %v, want %q", err, want)
+	}
+}
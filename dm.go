@@ -13,28 +13,72 @@ type DMService struct {
 // Represents a direct message -- a message between
 // two users who follow each other.
 type DirectMessage struct {
-	CreatedAt           string `json:"created_at"`
+	CreatedAt           Time   `json:"created_at"`
 	SenderScreenName    string `json:"sender_screen_name"`
 	Sender              *User  `json:"sender"`
 	Text                string `json:"text"`
 	RecipientScreenName string `json:"recipient_screen_name"`
-	Id                  string `json:"id"`
+	Id                  ID     `json:"id"`
 	Recipient           *User  `json:"recipient"`
-	RecipientId         string `json:"recipient_id"`
-	SenderId            string `json:"sender_id"`
+	RecipientId         ID     `json:"recipient_id"`
+	SenderId            ID     `json:"sender_id"`
+
+	RawJSON
+}
+
+func (m *DirectMessage) UnmarshalJSON(data []byte) error {
+	type directMessage DirectMessage
+	return m.decodeJSON(data, (*directMessage)(m))
+}
+
+func (m DirectMessage) MarshalJSON() ([]byte, error) {
+	type directMessage DirectMessage
+	return m.encodeJSON((*directMessage)(&m))
 }
 
 // A list of direct messages
 type DirectMessageList []DirectMessage
 
+// Options of the methods listing direct messages, List and Sent
+type DMOptions struct {
+	// Number of messages to return, up to 200
+	Count int
+	// Only return messages more recent than this one
+	SinceID ID
+	// Only return messages older than or equal to this one
+	MaxID ID
+	// Page of results to return. Only supported by Sent.
+	Page int
+	// Whether to include entities
+	IncludeEntities *bool
+	// Leave the last tweet of the sender and recipient out
+	SkipStatus bool
+	// Return the full text of messages longer than 140 characters
+	FullText bool
+}
+
+// Returns the optionals to pass to List and Sent
+func (do *DMOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if do == nil {
+		return opts
+	}
+	opts.setInt("count", do.Count)
+	opts.setID("since_id", do.SinceID)
+	opts.setID("max_id", do.MaxID)
+	opts.setInt("page", do.Page)
+	opts.setBoolPtr("include_entities", do.IncludeEntities)
+	opts.setBool("skip_status", do.SkipStatus)
+	opts.setBool("full_text", do.FullText)
+	return opts
+}
+
 // Returns the 20 most recent direct messages sent to the authenticating user.
 // Includes detailed information about the sender and recipient user. You can
 // request up to 200 direct messages per call, up to a maximum of 800 incoming DMs
 // See https://dev.twitter.com/docs/api/1.1/get/direct_messages
 func (dm *DMService) List(opts *Optionals) (messages *DirectMessageList, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+	opts = opts.Clone()
 	messages = &DirectMessageList{}
 	err = dm.Call("GET", "direct_messages", opts, messages)
 	return
@@ -45,9 +89,7 @@ func (dm *DMService) List(opts *Optionals) (messages *DirectMessageList, err err
 // request up to 200 direct messages per call, up to a maximum of 800 outgoing DMs.
 // See https://dev.twitter.com/docs/api/1.1/get/direct_messages/sent
 func (dm *DMService) Sent(opts *Optionals) (messages *DirectMessageList, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+	opts = opts.Clone()
 	messages = &DirectMessageList{}
 	err = dm.Call("GET", "direct_messages/sent", opts, messages)
 	return
@@ -55,11 +97,9 @@ func (dm *DMService) Sent(opts *Optionals) (messages *DirectMessageList, err err
 
 // Returns a single direct message, specified by an id parameter.
 // See https://dev.twitter.com/docs/api/1.1/get/direct_messages/show
-func (dm *DMService) Get(id int64, opts *Optionals) (message *DirectMessage, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	opts.Add("id", id)
+func (dm *DMService) Get(id ID, opts *Optionals) (message *DirectMessage, err error) {
+	opts = opts.Clone()
+	opts.Set("id", id)
 	message = &DirectMessage{}
 	err = dm.Call("GET", "direct_messages/show", opts, message)
 	return
@@ -69,11 +109,9 @@ func (dm *DMService) Get(id int64, opts *Optionals) (message *DirectMessage, err
 // The authenticating user must be the recipient of the specified direct
 // message.
 // See https://dev.twitter.com/docs/api/1.1/post/direct_messages/destroy
-func (dm *DMService) Destroy(id int64, opts *Optionals) (message *DirectMessage, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	opts.Add("id", id)
+func (dm *DMService) Destroy(id ID, opts *Optionals) (message *DirectMessage, err error) {
+	opts = opts.Clone()
+	opts.Set("id", id)
 	message = &DirectMessage{}
 	err = dm.Call("POST", "direct_messages/show", opts, message)
 	return
@@ -82,11 +120,9 @@ func (dm *DMService) Destroy(id int64, opts *Optionals) (message *DirectMessage,
 // Sends a new direct message to the specified user from the authenticating user.
 // See https://dev.twitter.com/docs/api/1.1/post/direct_messages/new
 func (dm *DMService) Send(screenname, text string, opts *Optionals) (message *DirectMessage, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	opts.Add("screen_name", screenname)
-	opts.Add("text", text)
+	opts = opts.Clone()
+	opts.Set("screen_name", screenname)
+	opts.Set("text", text)
 	message = &DirectMessage{}
 	err = dm.Call("POST", "direct_messages/new", opts, message)
 	return
@@ -165,6 +165,22 @@ To provide optional parameters, use tweetlib.Optionals
     opts.Add("long", -122.400612831116)
     tweet, err := client.Tweets.Update("Hello, world", opts)
 
+Most methods also have a typed options struct, named after the method or
+the family of methods it applies to, that encodes to the right parameters:
+
+    opts := &tweetlib.TimelineOptions{Count: 200, ExcludeReplies: true}
+    tweets, err := client.Tweets.HomeTimeline(opts.Optionals())
+
+Images, GIFs and videos are uploaded with client.Media and attached to
+tweets by id. Videos are uploaded in chunks and processed by Twitter before
+they can be used, which UploadAndWait waits for:
+
+    opts := &tweetlib.ChunkedUploadOptions{}
+    opts.MediaCategory = tweetlib.MediaCategoryTweetVideo
+    video, err := client.Media.UploadAndWait(ctx, file, size, "video/mp4", opts)
+    update := &tweetlib.UpdateOptions{MediaIds: []tweetlib.ID{video.MediaId}}
+    tweet, err := client.Tweets.Update("Hello, world", update.Optionals())
+
 
 There's also two ways of making arbitrary API calls. This is useful
 when you need to call a new API that is not directly supported
@@ -0,0 +1,178 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import "unicode/utf16"
+
+// Position of an entity within the text of a tweet: the offset of its
+// first character and the offset just past its last one.
+type Indices [2]int
+
+// Offset of the first character of the entity
+func (i Indices) Start() int { return i[0] }
+
+// Offset just past the last character of the entity
+func (i Indices) End() int { return i[1] }
+
+// Returns the part of s between the offsets start and end, expressed in
+// UTF-16 code units as entity indices are. Out of range offsets are
+// clamped to the bounds of s.
+func utf16Slice(s string, start, end int) string {
+	from, to := -1, len(s)
+	n := 0
+	for i, r := range s {
+		if from < 0 && n >= start {
+			from = i
+		}
+		if n >= end {
+			to = i
+			break
+		}
+		n += utf16.RuneLen(r)
+	}
+	if from < 0 || from > to {
+		return ""
+	}
+	return s[from:to]
+}
+
+// Entities that have been parsed out of the text of a tweet
+// See https://dev.twitter.com/overview/api/entities-in-twitter-objects
+type Entities struct {
+	Hashtags     []HashtagEntity `json:"hashtags"`
+	Symbols      []SymbolEntity  `json:"symbols"`
+	Urls         []URLEntity     `json:"urls"`
+	UserMentions []MentionEntity `json:"user_mentions"`
+	Media        []MediaEntity   `json:"media"`
+	Polls        []PollEntity    `json:"polls"`
+}
+
+// Extended entities hold every media attached to a tweet (up to four
+// photos, a video or an animated GIF) whereas Entities.Media only ever
+// holds the first one.
+// See https://dev.twitter.com/overview/api/entities-in-twitter-objects#extended_entities
+type ExtendedEntities struct {
+	Media []MediaEntity `json:"media"`
+}
+
+// A hashtag (e.g. #golang) found in the text
+type HashtagEntity struct {
+	Text    string  `json:"text"`
+	Indices Indices `json:"indices"`
+}
+
+// A cashtag (e.g. $TWTR) found in the text
+type SymbolEntity struct {
+	Text    string  `json:"text"`
+	Indices Indices `json:"indices"`
+}
+
+// A URL found in the text. Url holds the t.co link that actually appears in
+// the text.
+type URLEntity struct {
+	Url         string  `json:"url"`
+	DisplayUrl  string  `json:"display_url"`
+	ExpandedUrl string  `json:"expanded_url"`
+	Indices     Indices `json:"indices"`
+}
+
+// A user mentioned in the text
+type MentionEntity struct {
+	ScreenName string  `json:"screen_name"`
+	Name       string  `json:"name"`
+	Indices    Indices `json:"indices"`
+	IdStr      string  `json:"id_str"`
+	Id         ID      `json:"id"`
+}
+
+// Types of media
+const (
+	MediaPhoto       = "photo"
+	MediaVideo       = "video"
+	MediaAnimatedGif = "animated_gif"
+)
+
+// A photo, video or animated GIF attached to a tweet
+type MediaEntity struct {
+	Id                ID         `json:"id"`
+	IdStr             string     `json:"id_str"`
+	Indices           Indices    `json:"indices"`
+	MediaUrl          string     `json:"media_url"`
+	MediaUrlHttps     string     `json:"media_url_https"`
+	Url               string     `json:"url"`
+	DisplayUrl        string     `json:"display_url"`
+	ExpandedUrl       string     `json:"expanded_url"`
+	Type              string     `json:"type"`
+	Sizes             MediaSizes `json:"sizes"`
+	SourceStatusId    ID         `json:"source_status_id"`
+	SourceStatusIdStr string     `json:"source_status_id_str"`
+	SourceUserId      ID         `json:"source_user_id"`
+	SourceUserIdStr   string     `json:"source_user_id_str"`
+	ExtAltText        string     `json:"ext_alt_text"`
+	VideoInfo         *VideoInfo `json:"video_info"`
+}
+
+// Sizes available for a media. Photos can be fetched in a given size by
+// appending ":" and the size name (e.g. ":large") to MediaUrlHttps.
+type MediaSizes struct {
+	Thumb  MediaSize `json:"thumb"`
+	Small  MediaSize `json:"small"`
+	Medium MediaSize `json:"medium"`
+	Large  MediaSize `json:"large"`
+}
+
+// Dimensions of a media in a given size. Resize is either "fit" or "crop".
+type MediaSize struct {
+	Width  int    `json:"w"`
+	Height int    `json:"h"`
+	Resize string `json:"resize"`
+}
+
+// Details of a video or animated GIF. Only available through extended
+// entities.
+type VideoInfo struct {
+	AspectRatio    [2]int         `json:"aspect_ratio"`
+	DurationMillis int64          `json:"duration_millis"`
+	Variants       []VideoVariant `json:"variants"`
+}
+
+// A single encoding of a video. Bitrate is not set for streaming formats
+// such as application/x-mpegURL.
+type VideoVariant struct {
+	Bitrate     int    `json:"bitrate"`
+	ContentType string `json:"content_type"`
+	Url         string `json:"url"`
+}
+
+// Returns the MP4 variant with the highest bitrate, or nil if there is no
+// such variant.
+func (vi *VideoInfo) BestVariant() *VideoVariant {
+	var best *VideoVariant
+	for i := range vi.Variants {
+		v := &vi.Variants[i]
+		if v.ContentType != "video/mp4" {
+			continue
+		}
+		if best == nil || v.Bitrate > best.Bitrate {
+			best = v
+		}
+	}
+	return best
+}
+
+// A poll attached to a tweet
+type PollEntity struct {
+	Options         []PollOption `json:"options"`
+	EndDatetime     Time         `json:"end_datetime"`
+	DurationMinutes int          `json:"duration_minutes"`
+}
+
+// One of the choices of a poll
+type PollOption struct {
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+}
@@ -0,0 +1,91 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBestVariant(t *testing.T) {
+	var vi VideoInfo
+	err := json.Unmarshal([]byte(`{
+		"aspect_ratio": [16, 9],
+		"duration_millis": 30033,
+		"variants": [
+			{"bitrate": 832000, "content_type": "video/mp4", "url": "https://video.twimg.com/832.mp4"},
+			{"content_type": "application/x-mpegURL", "url": "https://video.twimg.com/pl.m3u8"},
+			{"bitrate": 2176000, "content_type": "video/mp4", "url": "https://video.twimg.com/2176.mp4"},
+			{"bitrate": 256000, "content_type": "video/mp4", "url": "https://video.twimg.com/256.mp4"}
+		]
+	}`), &vi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	best := vi.BestVariant()
+	if best == nil || best.Url != "https://video.twimg.com/2176.mp4" {
+		t.Errorf("BestVariant() = %+v, want the 2176000 bitrate mp4", best)
+	}
+
+	streamOnly := VideoInfo{Variants: []VideoVariant{
+		{ContentType: "application/x-mpegURL", Url: "https://video.twimg.com/pl.m3u8"},
+	}}
+	if best := streamOnly.BestVariant(); best != nil {
+		t.Errorf("BestVariant() without mp4 = %+v, want nil", best)
+	}
+	if best := (&VideoInfo{}).BestVariant(); best != nil {
+		t.Errorf("BestVariant() without variants = %+v, want nil", best)
+	}
+}
+
+func TestTweetMedia(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want []ID
+	}{
+		{
+			"no media",
+			`{"text": "hi", "entities": {}}`,
+			nil,
+		},
+		{
+			"entities only",
+			`{"entities": {"media": [{"id": 1}]}}`,
+			[]ID{1},
+		},
+		{
+			"extended entities hold every media",
+			`{"entities": {"media": [{"id": 1}]},
+			  "extended_entities": {"media": [{"id": 1}, {"id": 2}, {"id": 3}]}}`,
+			[]ID{1, 2, 3},
+		},
+		{
+			"extended tweet",
+			`{"truncated": true, "entities": {},
+			  "extended_tweet": {"full_text": "long", "entities": {"media": [{"id": 4}]},
+			    "extended_entities": {"media": [{"id": 4}, {"id": 5}]}}}`,
+			[]ID{4, 5},
+		},
+	}
+	for _, tt := range tests {
+		var tw Tweet
+		if err := json.Unmarshal([]byte(tt.json), &tw); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		media := tw.Media()
+		if len(media) != len(tt.want) {
+			t.Errorf("%s: got %d media, want %d", tt.name, len(media), len(tt.want))
+			continue
+		}
+		for i, m := range media {
+			if m.Id != tt.want[i] {
+				t.Errorf("%s: media %d has id %d, want %d", tt.name, i, m.Id, tt.want[i])
+			}
+		}
+	}
+}
@@ -2,6 +2,7 @@ package tweetlib
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 )
 
@@ -22,14 +23,14 @@ func NoBearerTokenError() *ApplicationOAuthError {
 // TwitterError represents an error generated by
 // the Twitter API
 type TwitterError struct {
-	Message string `json:message` // Error message
-	Code    int    `json:code`    // Error code
+	Message string `json:"message"` // Error message
+	Code    int    `json:"code"`    // Error code
 }
 
 // TwitterErrorReply: contains a list of errors returned
 // for a request to the Twitter API
 type TwitterErrorReply struct {
-	Errors []TwitterError `json:errors`
+	Errors []TwitterError `json:"errors"`
 }
 
 // Twitter error responses can actually contain
@@ -42,3 +43,26 @@ func (ter *TwitterErrorReply) String() string {
 	}
 	return buf.String()
 }
+
+// DecodeError is reported when a response from the Twitter API does not
+// match the types tweetlib decodes it into, which usually means Twitter
+// changed the type of a field. See Client.StrictDecoding.
+type DecodeError struct {
+	// The API call whose response could not be decoded (e.g. "statuses/show")
+	Endpoint string
+	// One entry per offending value. Field holds its location in the
+	// response (e.g. "entities.urls[0].indices")
+	Fields []*json.UnmarshalTypeError
+}
+
+func (e *DecodeError) Error() string {
+	buf := bytes.NewBufferString("")
+	fmt.Fprintf(buf, "cannot decode response of %s:", e.Endpoint)
+	for i, f := range e.Fields {
+		if i > 0 {
+			buf.WriteString(";")
+		}
+		fmt.Fprintf(buf, " %s (%s into %v)", f.Field, f.Value, f.Type)
+	}
+	return buf.String()
+}
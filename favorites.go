@@ -0,0 +1,96 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+// Groups favorites (likes) functions
+type FavoritesService struct {
+	*Client
+}
+
+// Options of List and ListPages
+type FavoritesOptions struct {
+	// Number of tweets to retrieve per page, up to 200
+	Count int
+	// Only return tweets more recent than this one
+	SinceID ID
+	// Only return tweets older than or equal to this one
+	MaxID ID
+	// Whether to include entities
+	IncludeEntities *bool
+}
+
+// Returns the optionals to pass to List and ListPages
+func (fo *FavoritesOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if fo == nil {
+		return opts
+	}
+	opts.setInt("count", fo.Count)
+	opts.setID("since_id", fo.SinceID)
+	opts.setID("max_id", fo.MaxID)
+	opts.setBoolPtr("include_entities", fo.IncludeEntities)
+	return opts
+}
+
+// Favorites (likes) the tweet specified in the id parameter as the
+// authenticating user. Returns the favorited tweet.
+// See https://dev.twitter.com/docs/api/1.1/post/favorites/create
+func (fs *FavoritesService) Create(id ID, opts *Optionals) (tweet *Tweet, err error) {
+	opts = opts.Clone()
+	opts.Set("id", id)
+	tweet = &Tweet{}
+	err = fs.Call("POST", "favorites/create", opts, tweet)
+	return
+}
+
+// Un-favorites the tweet specified in the id parameter as the
+// authenticating user. Returns the un-favorited tweet.
+// See https://dev.twitter.com/docs/api/1.1/post/favorites/destroy
+func (fs *FavoritesService) Destroy(id ID, opts *Optionals) (tweet *Tweet, err error) {
+	opts = opts.Clone()
+	opts.Set("id", id)
+	tweet = &Tweet{}
+	err = fs.Call("POST", "favorites/destroy", opts, tweet)
+	return
+}
+
+// Returns the 20 (by default) most recent tweets favorited by the user
+// indicated by screenName, or by the authenticating user if screenName is
+// empty.
+// See https://dev.twitter.com/docs/api/1.1/get/favorites/list
+func (fs *FavoritesService) List(screenName string, opts *Optionals) (tweets *TweetList, err error) {
+	opts = opts.Clone()
+	if screenName != "" {
+		opts.Set("screen_name", screenName)
+	}
+	tweets = &TweetList{}
+	err = fs.Call("GET", "favorites/list", opts, tweets)
+	return
+}
+
+// Calls fn with every page of the tweets favorited by a user, newest
+// first, until all of them have been returned or fn returns an error.
+// Return ErrStopPaging from fn to stop paging early. See List.
+//
+// Usage:
+//
+//	opts := &FavoritesOptions{Count: 200}
+//	err := client.Favorites.ListPages("golang", opts.Optionals(), func(tweets TweetList) error {
+//		for _, t := range tweets {
+//			fmt.Println(t.Text)
+//		}
+//		return nil
+//	})
+func (fs *FavoritesService) ListPages(screenName string, opts *Optionals, fn func(TweetList) error) error {
+	return maxIDPages(opts, func(opts *Optionals) (TweetList, error) {
+		tweets, err := fs.List(screenName, opts)
+		if err != nil {
+			return nil, err
+		}
+		return *tweets, nil
+	}, fn)
+}
@@ -6,62 +6,150 @@
 
 package tweetlib
 
-import "strconv"
-
 type FriendsService struct {
 	*Client
 }
 
+// A page of a cursored collection of user ids
 type Cursor struct {
-	Next        int64   `json:"next_cursor"`
-	NextStr     string  `json:"next_cursor_str"`
-	Previous    int64   `json:"previous_cursor"`
-	PreviousStr string  `json:"previous_cursor_str"`
-	IDs         []int64 `json:"ids"`
+	CursorPosition
+	IDs []ID `json:"ids"`
 }
 
-// IDs returns a cursored collection of user IDs.
-// See https://dev.twitter.com/docs/api/1.1/get/friends/ids
-func (ls *FriendsService) IDs(screenName string, userID int64, cursor int64, opts *Optionals) (IDs *Cursor, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	switch {
-	case screenName != "":
-		opts.Add("screen_name", screenName)
-	default:
-		opts.Add("user_id", userID)
+// Options of the methods returning cursored collections of ids
+type CursorOptions struct {
+	// Number of ids to return per page, up to 5000
+	Count int
+	// Return ids as strings. Unneeded, as ID decodes from both forms.
+	StringifyIDs bool
+}
+
+// Returns the optionals to pass to FriendsService.IDs and
+// FollowersService.IDs
+func (co *CursorOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if co == nil {
+		return opts
 	}
-	if cursor != 0 {
-		opts.Add("cursor", strconv.FormatInt(cursor, 10))
+	opts.setInt("count", co.Count)
+	opts.setBool("stringify_ids", co.StringifyIDs)
+	return opts
+}
+
+// Options of the methods returning cursored collections of users
+type UserCursorOptions struct {
+	// Number of users to return per page, up to 200
+	Count int
+	// Leave the last tweet of each user out
+	SkipStatus bool
+	// Whether to include the entities of each user
+	IncludeUserEntities *bool
+}
+
+// Returns the optionals to pass to FriendsService.List and
+// FollowersService.List
+func (co *UserCursorOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if co == nil {
+		return opts
 	}
+	opts.setInt("count", co.Count)
+	opts.setBool("skip_status", co.SkipStatus)
+	opts.setBoolPtr("include_user_entities", co.IncludeUserEntities)
+	return opts
+}
+
+// Requests a page of a cursored collection about the user given by screen
+// name or by id
+func (c *Client) userCursor(endpoint, screenName string, userID ID, cursor int64, opts *Optionals, resp interface{}) error {
+	opts = opts.Clone()
+	opts.setUser(screenName, userID)
+	opts.setCursor(cursor)
+	return c.Call("GET", endpoint, opts, resp)
+}
+
+// Calls fn with every page of a cursored collection of user ids
+func (c *Client) idsPages(endpoint, screenName string, userID ID, opts *Optionals, fn func([]ID) error) error {
+	return cursorPages(opts, func(opts *Optionals) (*CursorPosition, error) {
+		IDs := &Cursor{}
+		if err := c.userCursor(endpoint, screenName, userID, 0, opts, IDs); err != nil {
+			return nil, err
+		}
+		return &IDs.CursorPosition, fn(IDs.IDs)
+	})
+}
+
+// Calls fn with every page of a cursored collection of users
+func (c *Client) usersPages(endpoint, screenName string, userID ID, opts *Optionals, fn func([]User) error) error {
+	return cursorPages(opts, func(opts *Optionals) (*CursorPosition, error) {
+		users := &UserCursor{}
+		if err := c.userCursor(endpoint, screenName, userID, 0, opts, users); err != nil {
+			return nil, err
+		}
+		return &users.CursorPosition, fn(users.Users)
+	})
+}
+
+// IDs returns a cursored collection of user IDs.
+// See https://dev.twitter.com/docs/api/1.1/get/friends/ids
+func (ls *FriendsService) IDs(screenName string, userID ID, cursor int64, opts *Optionals) (IDs *Cursor, err error) {
 	IDs = &Cursor{}
-	err = ls.Call("GET", "friends/ids", opts, IDs)
+	err = ls.userCursor("friends/ids", screenName, userID, cursor, opts, IDs)
 	return
 }
 
+// Calls fn with every page of the ids of the users the specified user
+// follows, until all of them have been returned or fn returns an error.
+// Return ErrStopPaging from fn to stop paging early.
+func (ls *FriendsService) IDsPages(screenName string, userID ID, opts *Optionals, fn func([]ID) error) error {
+	return ls.idsPages("friends/ids", screenName, userID, opts, fn)
+}
+
+// Returns a cursored collection of the users the specified user follows,
+// most recently followed first. See UserCursorOptions.
+// See https://dev.twitter.com/docs/api/1.1/get/friends/list
+func (ls *FriendsService) List(screenName string, userID ID, cursor int64, opts *Optionals) (users *UserCursor, err error) {
+	users = &UserCursor{}
+	err = ls.userCursor("friends/list", screenName, userID, cursor, opts, users)
+	return
+}
+
+// Calls fn with every page of the users the specified user follows. See
+// IDsPages.
+func (ls *FriendsService) ListPages(screenName string, userID ID, opts *Optionals, fn func([]User) error) error {
+	return ls.usersPages("friends/list", screenName, userID, opts, fn)
+}
+
 type FollowersService struct {
 	*Client
 }
 
 // IDs returns a cursored collection of user IDs.
 // See https://dev.twitter.com/docs/api/1.1/get/followers/ids
-func (ls *FollowersService) IDs(screenName string, userID int64, cursor int64, opts *Optionals) (IDs *Cursor, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	switch {
-	case screenName != "":
-		opts.Add("screen_name", screenName)
-	default:
-		opts.Add("user_id", userID)
-	}
-	if cursor != 0 {
-		opts.Add("cursor", strconv.FormatInt(cursor, 10))
-	}
+func (ls *FollowersService) IDs(screenName string, userID ID, cursor int64, opts *Optionals) (IDs *Cursor, err error) {
 	IDs = &Cursor{}
-	err = ls.Call("GET", "followers/ids", opts, IDs)
+	err = ls.userCursor("followers/ids", screenName, userID, cursor, opts, IDs)
 	return
 }
 
-// https://dev.twitter.com/docs/api/1.1/get/followers/ids
+// Calls fn with every page of the ids of the followers of the specified
+// user, until all of them have been returned or fn returns an error.
+// Return ErrStopPaging from fn to stop paging early.
+func (ls *FollowersService) IDsPages(screenName string, userID ID, opts *Optionals, fn func([]ID) error) error {
+	return ls.idsPages("followers/ids", screenName, userID, opts, fn)
+}
+
+// Returns a cursored collection of the followers of the specified user,
+// most recent first. See UserCursorOptions.
+// See https://dev.twitter.com/docs/api/1.1/get/followers/list
+func (ls *FollowersService) List(screenName string, userID ID, cursor int64, opts *Optionals) (users *UserCursor, err error) {
+	users = &UserCursor{}
+	err = ls.userCursor("followers/list", screenName, userID, cursor, opts, users)
+	return
+}
+
+// Calls fn with every page of the followers of the specified user. See
+// IDsPages.
+func (ls *FollowersService) ListPages(screenName string, userID ID, opts *Optionals, fn func([]User) error) error {
+	return ls.usersPages("followers/list", screenName, userID, opts, fn)
+}
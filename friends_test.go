@@ -0,0 +1,111 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+func TestIDsPages(t *testing.T) {
+	pages := []string{`[1, 2, 3]`, `["4", "5"]`, `[6]`}
+	for _, tt := range []struct {
+		path  string
+		pages func(c *Client, fn func([]ID) error) error
+	}{
+		{"/friends/ids.json", func(c *Client, fn func([]ID) error) error {
+			return c.Friends.IDsPages("", 783214, (&CursorOptions{Count: 3}).Optionals(), fn)
+		}},
+		{"/followers/ids.json", func(c *Client, fn func([]ID) error) error {
+			return c.Followers.IDsPages("", 783214, (&CursorOptions{Count: 3}).Optionals(), fn)
+		}},
+	} {
+		var queries []url.Values
+		c := cursorServer(t, tt.path, "ids", pages, &queries)
+		var ids []ID
+		err := tt.pages(c, func(page []ID) error {
+			ids = append(ids, page...)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if fmt.Sprint(ids) != "[1 2 3 4 5 6]" {
+			t.Errorf("%s: ids = %v", tt.path, ids)
+		}
+		if got := fmt.Sprint(cursors(queries)); got != "[-1 1 2]" {
+			t.Errorf("%s: cursors = %s", tt.path, got)
+		}
+		for _, q := range queries {
+			if q.Get("user_id") != "783214" || q.Get("count") != "3" || q.Get("screen_name") != "" {
+				t.Errorf("%s: query %v", tt.path, q)
+			}
+		}
+	}
+}
+
+func TestUsersPages(t *testing.T) {
+	pages := []string{`[{"id": 1, "screen_name": "a"}]`, `[{"id": 2, "screen_name": "b"}, {"id": 3, "screen_name": "c"}]`}
+	for _, tt := range []struct {
+		path  string
+		pages func(c *Client, fn func([]User) error) error
+	}{
+		{"/friends/list.json", func(c *Client, fn func([]User) error) error {
+			return c.Friends.ListPages("golang", 0, nil, fn)
+		}},
+		{"/followers/list.json", func(c *Client, fn func([]User) error) error {
+			return c.Followers.ListPages("golang", 0, nil, fn)
+		}},
+	} {
+		var queries []url.Values
+		c := cursorServer(t, tt.path, "users", pages, &queries)
+		var names []string
+		err := tt.pages(c, func(users []User) error {
+			for _, u := range users {
+				names = append(names, u.ScreenName)
+			}
+			if len(names) == 3 {
+				// ending on the last page is the same as running out of them
+				return ErrStopPaging
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if fmt.Sprint(names) != "[a b c]" || fmt.Sprint(cursors(queries)) != "[-1 1]" {
+			t.Errorf("%s: got %v with cursors %v", tt.path, names, cursors(queries))
+		}
+		for _, q := range queries {
+			if q.Get("screen_name") != "golang" || q.Get("user_id") != "" {
+				t.Errorf("%s: query %v", tt.path, q)
+			}
+		}
+	}
+}
+
+func TestUserCursorPage(t *testing.T) {
+	var queries []url.Values
+	c := cursorServer(t, "/friends/ids.json", "ids", []string{`[1]`, `[2]`, `[3]`}, &queries)
+	page, err := c.Friends.IDs("", 12, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(page.IDs) != "[2]" || page.Next != 2 || page.NextStr != "2" || page.Previous != -1 {
+		t.Errorf("page = %+v", page)
+	}
+	if q := queries[0]; q.Get("cursor") != "1" || q.Get("user_id") != "12" {
+		t.Errorf("query = %v", q)
+	}
+
+	// a zero cursor is left out, for Twitter to pick the first page
+	c.Friends.IDs("", 12, 0, nil)
+	if q := queries[1]; q.Has("cursor") {
+		t.Errorf("query without cursor = %v", q)
+	}
+}
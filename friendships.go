@@ -0,0 +1,246 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import "errors"
+
+// Groups functions to follow users and inspect relationships between them
+type FriendshipsService struct {
+	*Client
+}
+
+// Maximum number of users friendships/lookup accepts per call
+const maxFriendshipsPerLookup = 100
+
+// Relationship between two users, as seen from each of them
+type Relationship struct {
+	Source RelationshipSide `json:"source"`
+	Target RelationshipSide `json:"target"`
+}
+
+// One of the users of a relationship. Fields other than the user's
+// identity and following state are only filled for the source, and only
+// when it is the authenticating user.
+type RelationshipSide struct {
+	Id                   ID     `json:"id"`
+	IdStr                string `json:"id_str"`
+	ScreenName           string `json:"screen_name"`
+	Following            bool   `json:"following"`
+	FollowedBy           bool   `json:"followed_by"`
+	FollowingReceived    bool   `json:"following_received"`
+	FollowingRequested   bool   `json:"following_requested"`
+	NotificationsEnabled bool   `json:"notifications_enabled"`
+	CanDM                bool   `json:"can_dm"`
+	Blocking             bool   `json:"blocking"`
+	BlockedBy            bool   `json:"blocked_by"`
+	Muting               bool   `json:"muting"`
+	WantRetweets         bool   `json:"want_retweets"`
+	AllReplies           bool   `json:"all_replies"`
+	MarkedSpam           bool   `json:"marked_spam"`
+}
+
+// Connections a user may have with the authenticating user, as listed in
+// Friendship.Connections
+const (
+	ConnectionFollowing          = "following"
+	ConnectionFollowingRequested = "following_requested"
+	ConnectionFollowedBy         = "followed_by"
+	ConnectionBlocking           = "blocking"
+	ConnectionMuting             = "muting"
+	ConnectionNone               = "none"
+)
+
+// Relationship of the authenticating user with another user, as returned
+// by Lookup
+type Friendship struct {
+	Name        string   `json:"name"`
+	ScreenName  string   `json:"screen_name"`
+	Id          ID       `json:"id"`
+	IdStr       string   `json:"id_str"`
+	Connections []string `json:"connections"`
+}
+
+// Reports whether the friendship has the given connection, one of the
+// Connection constants
+func (f *Friendship) Has(connection string) bool {
+	for _, c := range f.Connections {
+		if c == connection {
+			return true
+		}
+	}
+	return false
+}
+
+// A list of friendships
+type FriendshipList []Friendship
+
+// Options of Update. Only the settings that are set are updated.
+type FriendshipOptions struct {
+	// Whether to receive notifications of the user's tweets on the
+	// authenticating user's device
+	Device *bool
+	// Whether to see the user's retweets in the home timeline
+	Retweets *bool
+}
+
+// Returns the optionals to pass to Update
+func (fo *FriendshipOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if fo == nil {
+		return opts
+	}
+	opts.setBoolPtr("device", fo.Device)
+	opts.setBoolPtr("retweets", fo.Retweets)
+	return opts
+}
+
+// Follows the user specified by screen name or by id as the authenticating
+// user. Returns the followed user. Pass the "follow" optional parameter to
+// also enable notifications.
+// See https://dev.twitter.com/docs/api/1.1/post/friendships/create
+func (fs *FriendshipsService) Create(screenName string, userID ID, opts *Optionals) (user *User, err error) {
+	return fs.userAction("friendships/create", screenName, userID, opts)
+}
+
+// Unfollows the user specified by screen name or by id as the
+// authenticating user. Returns the unfollowed user.
+// See https://dev.twitter.com/docs/api/1.1/post/friendships/destroy
+func (fs *FriendshipsService) Destroy(screenName string, userID ID, opts *Optionals) (user *User, err error) {
+	return fs.userAction("friendships/destroy", screenName, userID, opts)
+}
+
+// POSTs to an endpoint acting on the user given by screen name or by id
+// and returns the user
+func (c *Client) userAction(endpoint, screenName string, userID ID, opts *Optionals) (user *User, err error) {
+	opts = opts.Clone()
+	opts.setUser(screenName, userID)
+	user = &User{}
+	err = c.Call("POST", endpoint, opts, user)
+	return
+}
+
+// Enables or disables retweets and device notifications from the
+// specified user, see FriendshipOptions.
+// See https://dev.twitter.com/docs/api/1.1/post/friendships/update
+func (fs *FriendshipsService) Update(screenName string, userID ID, opts *Optionals) (relationship *Relationship, err error) {
+	opts = opts.Clone()
+	opts.setUser(screenName, userID)
+	return fs.relationship("POST", "friendships/update", opts)
+}
+
+// Returns the relationship between two users. The source user defaults to
+// the authenticating user when both sourceScreenName and sourceID are
+// empty, while the target user must be given.
+// See https://dev.twitter.com/docs/api/1.1/get/friendships/show
+func (fs *FriendshipsService) Show(sourceScreenName string, sourceID ID, targetScreenName string, targetID ID, opts *Optionals) (relationship *Relationship, err error) {
+	opts = opts.Clone()
+	switch {
+	case sourceScreenName != "":
+		opts.Set("source_screen_name", sourceScreenName)
+	case sourceID != 0:
+		opts.Set("source_id", sourceID)
+	}
+	switch {
+	case targetScreenName != "":
+		opts.Set("target_screen_name", targetScreenName)
+	case targetID != 0:
+		opts.Set("target_id", targetID)
+	default:
+		return nil, errors.New("no target screen name or user id given")
+	}
+	return fs.relationship("GET", "friendships/show", opts)
+}
+
+func (fs *FriendshipsService) relationship(method, endpoint string, opts *Optionals) (relationship *Relationship, err error) {
+	ret := &struct {
+		Relationship *Relationship `json:"relationship"`
+	}{&Relationship{}}
+	err = fs.Call(method, endpoint, opts, ret)
+	return ret.Relationship, err
+}
+
+// Returns the relationships of the authenticating user with the users
+// given by screen name or by id. Twitter accepts up to 100 users per call,
+// so larger batches are looked up in several calls.
+// See https://dev.twitter.com/docs/api/1.1/get/friendships/lookup
+func (fs *FriendshipsService) Lookup(screenNames []string, userIDs []ID, opts *Optionals) (friendships *FriendshipList, err error) {
+	if len(screenNames) == 0 && len(userIDs) == 0 {
+		return nil, errors.New("no screen names or user ids given")
+	}
+	friendships = &FriendshipList{}
+	call := func(param string, users interface{}) error {
+		o := opts.Clone()
+		o.Set(param, users)
+		page := FriendshipList{}
+		if err := fs.Call("GET", "friendships/lookup", o, &page); err != nil {
+			return err
+		}
+		*friendships = append(*friendships, page...)
+		return nil
+	}
+	if err = userBatches(screenNames, userIDs, maxFriendshipsPerLookup, call); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Returns the page at cursor, from -1 for the first page, of the ids of
+// the users who asked to follow the protected authenticating user, or use
+// IncomingPages.
+// See https://dev.twitter.com/docs/api/1.1/get/friendships/incoming
+func (fs *FriendshipsService) Incoming(cursor int64, opts *Optionals) (IDs *Cursor, err error) {
+	return fs.idsCursor("friendships/incoming", cursor, opts)
+}
+
+// Calls fn with every page of the ids returned by Incoming, until all of
+// them have been returned or fn returns an error. Return ErrStopPaging
+// from fn to stop paging early.
+func (fs *FriendshipsService) IncomingPages(opts *Optionals, fn func([]ID) error) error {
+	return cursorPages(opts, func(opts *Optionals) (*CursorPosition, error) {
+		IDs, err := fs.Incoming(0, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &IDs.CursorPosition, fn(IDs.IDs)
+	})
+}
+
+// Returns a page of the ids of the protected users the authenticating user
+// asked to follow. See Incoming.
+// See https://dev.twitter.com/docs/api/1.1/get/friendships/outgoing
+func (fs *FriendshipsService) Outgoing(cursor int64, opts *Optionals) (IDs *Cursor, err error) {
+	return fs.idsCursor("friendships/outgoing", cursor, opts)
+}
+
+func (fs *FriendshipsService) idsCursor(endpoint string, cursor int64, opts *Optionals) (IDs *Cursor, err error) {
+	opts = opts.Clone()
+	opts.setCursor(cursor)
+	IDs = &Cursor{}
+	err = fs.Call("GET", endpoint, opts, IDs)
+	return
+}
+
+// Calls fn with every page of the ids returned by Outgoing. See
+// IncomingPages.
+func (fs *FriendshipsService) OutgoingPages(opts *Optionals, fn func([]ID) error) error {
+	return cursorPages(opts, func(opts *Optionals) (*CursorPosition, error) {
+		IDs, err := fs.Outgoing(0, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &IDs.CursorPosition, fn(IDs.IDs)
+	})
+}
+
+// Returns the ids of the users the authenticating user does not want to
+// see retweets from
+// See https://dev.twitter.com/docs/api/1.1/get/friendships/no_retweets/ids
+func (fs *FriendshipsService) NoRetweetsIDs(opts *Optionals) (IDs *IDList, err error) {
+	IDs = &IDList{}
+	err = fs.Call("GET", "friendships/no_retweets/ids", opts.Clone(), IDs)
+	return
+}
@@ -0,0 +1,184 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestUserBatches(t *testing.T) {
+	ids := func(n int) []ID {
+		s := make([]ID, n)
+		for i := range s {
+			s[i] = ID(i + 1)
+		}
+		return s
+	}
+	tests := []struct {
+		name        string
+		screenNames []string
+		userIDs     []ID
+		size        int
+		want        []string
+	}{
+		{"nothing", nil, nil, 3, nil},
+		{"under the size", []string{"a", "b"}, nil, 3, []string{"screen_name=a,b"}},
+		{"exactly the size", nil, ids(3), 3, []string{"user_id=1,2,3"}},
+		{"over the size", nil, ids(7), 3, []string{"user_id=1,2,3", "user_id=4,5,6", "user_id=7"}},
+		{
+			"screen names first",
+			[]string{"a", "b", "c", "d"}, ids(2), 3,
+			[]string{"screen_name=a,b,c", "screen_name=d", "user_id=1,2"},
+		},
+	}
+	for _, tt := range tests {
+		var got []string
+		err := userBatches(tt.screenNames, tt.userIDs, tt.size, func(param string, users interface{}) error {
+			got = append(got, param+"="+encodeValue(users))
+			return nil
+		})
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: got calls %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// the first error stops the batches
+	fail := errors.New("fail")
+	calls := 0
+	err := userBatches([]string{"a", "b"}, ids(2), 1, func(string, interface{}) error {
+		calls++
+		return fail
+	})
+	if err != fail || calls != 1 {
+		t.Errorf("got %v after %d calls, want the error after the first call", err, calls)
+	}
+}
+
+func TestFriendshipsShow(t *testing.T) {
+	var query string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"relationship": {
+			"source": {"id": 8376, "id_str": "8376", "screen_name": "bob", "following": true,
+				"followed_by": false, "following_received": null, "following_requested": null,
+				"notifications_enabled": null, "can_dm": false, "blocking": false, "blocked_by": false,
+				"muting": false, "want_retweets": true, "all_replies": null, "marked_spam": false},
+			"target": {"id": 12, "id_str": "12", "screen_name": "jack", "following": false,
+				"followed_by": true, "following_received": null, "following_requested": null}
+		}}`))
+	})
+	rel, err := c.Friendships.Show("", 8376, "", 12, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "source_id=8376&target_id=12" {
+		t.Errorf("query = %s", query)
+	}
+	if rel.Source.Id != 8376 || rel.Source.ScreenName != "bob" || !rel.Source.Following ||
+		rel.Source.FollowedBy || !rel.Source.WantRetweets || rel.Source.CanDM {
+		t.Errorf("source = %+v", rel.Source)
+	}
+	if rel.Target.Id != 12 || rel.Target.ScreenName != "jack" || rel.Target.Following || !rel.Target.FollowedBy {
+		t.Errorf("target = %+v", rel.Target)
+	}
+
+	if _, err = c.Friendships.Show("", 0, "jack", 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if query != "target_screen_name=jack" {
+		t.Errorf("query with the authenticating user as source = %s", query)
+	}
+
+	query = ""
+	if _, err = c.Friendships.Show("bob", 0, "", 0, nil); err == nil || query != "" {
+		t.Errorf("Show without a target = %v after requesting %q, want an error without a request", err, query)
+	}
+}
+
+func TestFriendshipsLookup(t *testing.T) {
+	var queries []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		var page []string
+		if ids := r.FormValue("user_id"); ids != "" {
+			for _, id := range strings.Split(ids, ",") {
+				page = append(page, fmt.Sprintf(`{"id": %s, "screen_name": "u%s", "connections": ["following", "followed_by"]}`, id, id))
+			}
+		}
+		if name := r.FormValue("screen_name"); name != "" {
+			page = append(page, fmt.Sprintf(`{"id": 1000, "screen_name": "%s", "connections": ["none"]}`, name))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(page, ","))
+	})
+	ids := make([]ID, 150)
+	for i := range ids {
+		ids[i] = ID(i + 1)
+	}
+	friendships, err := c.Friendships.Lookup([]string{"jack"}, ids, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 3 || len(*friendships) != 151 {
+		t.Fatalf("got %d friendships in %d calls, want 151 in 3", len(*friendships), len(queries))
+	}
+	if f := (*friendships)[0]; f.ScreenName != "jack" || !f.Has(ConnectionNone) || f.Has(ConnectionFollowing) {
+		t.Errorf("first friendship = %+v", f)
+	}
+	if f := (*friendships)[150]; f.Id != 150 || !f.Has(ConnectionFollowing) || !f.Has(ConnectionFollowedBy) || f.Has(ConnectionBlocking) {
+		t.Errorf("last friendship = %+v", f)
+	}
+
+	if _, err := c.Friendships.Lookup(nil, nil, nil); err == nil || err.Error() != "no screen names or user ids given" {
+		t.Errorf("Lookup without users = %v, want an error", err)
+	}
+}
+
+func TestFriendshipsIDs(t *testing.T) {
+	for _, tt := range []struct {
+		path string
+		get  func(c *Client, cursor int64) (*Cursor, error)
+	}{
+		{"/friendships/incoming.json", func(c *Client, cursor int64) (*Cursor, error) { return c.Friendships.Incoming(cursor, nil) }},
+		{"/friendships/outgoing.json", func(c *Client, cursor int64) (*Cursor, error) { return c.Friendships.Outgoing(cursor, nil) }},
+	} {
+		var queries []url.Values
+		c := cursorServer(t, tt.path, "ids", []string{"[1, 2]", "[3]"}, &queries)
+		first, err := tt.get(c, -1)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		second, err := tt.get(c, first.Next)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if fmt.Sprint(first.IDs, second.IDs, cursors(queries)) != "[1 2] [3] [-1 1]" {
+			t.Errorf("%s: got %v, %v with cursors %q", tt.path, first.IDs, second.IDs, cursors(queries))
+		}
+	}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/friendships/no_retweets/ids.json" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		w.Write([]byte(`[8376, 12]`))
+	})
+	ids, err := c.Friendships.NoRetweetsIDs(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(*ids) != "[8376 12]" {
+		t.Errorf("NoRetweetsIDs = %v", *ids)
+	}
+}
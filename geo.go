@@ -0,0 +1,181 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import "net/url"
+
+// Groups geo functions, which resolve coordinates and queries to places
+type GeoService struct {
+	*Client
+}
+
+// A GeoJSON point. As mandated by GeoJSON, Coordinates holds the longitude
+// first and the latitude second.
+// See https://dev.twitter.com/overview/api/tweets#obj-coordinates
+type Point struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// Returns a point at the given latitude and longitude
+func NewPoint(lat, long float64) *Point {
+	return &Point{Type: "Point", Coordinates: [2]float64{long, lat}}
+}
+
+// Latitude of the point
+func (p *Point) Lat() float64 {
+	return p.Coordinates[1]
+}
+
+// Longitude of the point
+func (p *Point) Long() float64 {
+	return p.Coordinates[0]
+}
+
+// The deprecated geo member of tweets. Unlike in a Point, Coordinates holds
+// the latitude first and the longitude second.
+type GeoPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// Latitude of the point
+func (g *GeoPoint) Lat() float64 {
+	return g.Coordinates[0]
+}
+
+// Longitude of the point
+func (g *GeoPoint) Long() float64 {
+	return g.Coordinates[1]
+}
+
+// Returns the same location as a GeoJSON point
+func (g *GeoPoint) Point() *Point {
+	return NewPoint(g.Lat(), g.Long())
+}
+
+// A named location, such as a city or a point of interest, that a tweet
+// can be associated with.
+// See https://dev.twitter.com/overview/api/places
+type Place struct {
+	Id          string            `json:"id"`
+	Url         string            `json:"url"`
+	PlaceType   string            `json:"place_type"`
+	Name        string            `json:"name"`
+	FullName    string            `json:"full_name"`
+	CountryCode string            `json:"country_code"`
+	Country     string            `json:"country"`
+	BoundingBox *BoundingBox      `json:"bounding_box"`
+	Attributes  map[string]string `json:"attributes"`
+	// Places this one is part of, e.g. the city of a neighborhood. Only
+	// returned by the geo methods.
+	ContainedWithin []Place `json:"contained_within"`
+}
+
+// A list of places
+type PlaceList []Place
+
+// A GeoJSON polygon enclosing a place. Coordinates holds a single ring of
+// [longitude, latitude] pairs.
+type BoundingBox struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+// Returns the center of the bounding box, or the zero point if the box
+// is empty.
+func (b *BoundingBox) Center() *Point {
+	var lat, long float64
+	n := 0
+	for _, ring := range b.Coordinates {
+		for _, c := range ring {
+			long += c[0]
+			lat += c[1]
+			n++
+		}
+	}
+	if n == 0 {
+		return NewPoint(0, 0)
+	}
+	return NewPoint(lat/float64(n), long/float64(n))
+}
+
+// Options of ReverseGeocode and Search
+type GeoSearchOptions struct {
+	// Free-form text to match against place names. Only supported by
+	// Search.
+	Query string
+	// Location to search around. Search requires either Location, Query or
+	// IP.
+	Location *Point
+	// IP address to search around. Only supported by Search.
+	IP string
+	// Radius around Location places are searched within, in meters or
+	// with a unit (e.g. "5ft")
+	Accuracy string
+	// Minimal type of the places to return: "poi", "neighborhood", "city",
+	// "admin" or "country"
+	Granularity string
+	// Hint on the number of places to return
+	MaxResults int
+	// Id of a place the results must be within. Only supported by Search.
+	ContainedWithin string
+}
+
+// Returns the optionals to pass to ReverseGeocode and Search
+func (so *GeoSearchOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if so == nil {
+		return opts
+	}
+	opts.setString("query", so.Query)
+	if so.Location != nil {
+		opts.Set("lat", so.Location.Lat())
+		opts.Set("long", so.Location.Long())
+	}
+	opts.setString("ip", so.IP)
+	opts.setString("accuracy", so.Accuracy)
+	opts.setString("granularity", so.Granularity)
+	opts.setInt("max_results", so.MaxResults)
+	opts.setString("contained_within", so.ContainedWithin)
+	return opts
+}
+
+// Returns a place, given by its id, along with the places it is within
+// See https://dev.twitter.com/docs/api/1.1/get/geo/id/%3Aplace_id
+func (gs *GeoService) Place(placeID string) (place *Place, err error) {
+	place = &Place{}
+	err = gs.Call("GET", "geo/id/"+url.PathEscape(placeID), nil, place)
+	return
+}
+
+// Returns up to 20 places that can be attached to a tweet sent from the
+// given coordinates, e.g. with UpdateOptions.PlaceId. See GeoSearchOptions.
+// See https://dev.twitter.com/docs/api/1.1/get/geo/reverse_geocode
+func (gs *GeoService) ReverseGeocode(lat, long float64, opts *Optionals) (places *PlaceList, err error) {
+	opts = opts.Clone()
+	opts.Set("lat", lat)
+	opts.Set("long", long)
+	return gs.places("geo/reverse_geocode", opts)
+}
+
+// Searches for places that can be attached to a tweet, by query, location
+// or IP address. See GeoSearchOptions.
+// See https://dev.twitter.com/docs/api/1.1/get/geo/search
+func (gs *GeoService) Search(opts *Optionals) (places *PlaceList, err error) {
+	return gs.places("geo/search", opts.Clone())
+}
+
+func (gs *GeoService) places(endpoint string, opts *Optionals) (places *PlaceList, err error) {
+	ret := &struct {
+		Result struct {
+			Places PlaceList `json:"places"`
+		} `json:"result"`
+	}{}
+	err = gs.Call("GET", endpoint, opts, ret)
+	return &ret.Result.Places, err
+}
@@ -0,0 +1,133 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestTweetLocation(t *testing.T) {
+	var tw Tweet
+	err := json.Unmarshal([]byte(`{
+		"geo": {"type": "Point", "coordinates": [37.7821, -122.4006]},
+		"coordinates": {"type": "Point", "coordinates": [-122.4006, 37.7821]},
+		"place": {
+			"id": "5a110d312052166f",
+			"place_type": "city",
+			"full_name": "San Francisco, CA",
+			"bounding_box": {"type": "Polygon", "coordinates": [[
+				[-122.5, 37.7], [-122.3, 37.7], [-122.3, 37.9], [-122.5, 37.9]
+			]]}
+		}
+	}`), &tw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const lat, long = 37.7821, -122.4006
+	if tw.Geo.Lat() != lat || tw.Geo.Long() != long {
+		t.Errorf("Geo = %v, %v, want %v, %v", tw.Geo.Lat(), tw.Geo.Long(), lat, long)
+	}
+	if tw.Coordinates.Lat() != lat || tw.Coordinates.Long() != long {
+		t.Errorf("Coordinates = %v, %v, want %v, %v", tw.Coordinates.Lat(), tw.Coordinates.Long(), lat, long)
+	}
+	if p := tw.Geo.Point(); *p != *tw.Coordinates {
+		t.Errorf("Geo.Point() = %+v, want %+v", p, tw.Coordinates)
+	}
+	c := tw.Place.BoundingBox.Center()
+	if c.Lat() < 37.79 || c.Lat() > 37.81 || c.Long() < -122.41 || c.Long() > -122.39 {
+		t.Errorf("Center() = %v, %v", c.Lat(), c.Long())
+	}
+}
+
+func TestNewPoint(t *testing.T) {
+	p := NewPoint(37.78, -122.40)
+	if p.Coordinates != [2]float64{-122.40, 37.78} {
+		t.Errorf("Coordinates = %v, want longitude first", p.Coordinates)
+	}
+	if p.Lat() != 37.78 || p.Long() != -122.40 {
+		t.Errorf("Lat, Long = %v, %v", p.Lat(), p.Long())
+	}
+}
+
+const geoPlaceJSON = `{
+	"id": "5a110d312052166f", "name": "San Francisco", "place_type": "city",
+	"full_name": "San Francisco, CA", "country_code": "US",
+	"contained_within": [
+		{"id": "fbd6d2f5a4e4a15e", "name": "California", "place_type": "admin"}
+	]
+}`
+
+func TestGeoPlace(t *testing.T) {
+	var path string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(geoPlaceJSON))
+	})
+	place, err := c.Geo.Place("5a110d312052166f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "/geo/id/5a110d312052166f.json" {
+		t.Errorf("requested %s", path)
+	}
+	if place.Id != "5a110d312052166f" || place.PlaceType != "city" {
+		t.Errorf("place = %+v", place)
+	}
+	if len(place.ContainedWithin) != 1 || place.ContainedWithin[0].Name != "California" ||
+		place.ContainedWithin[0].PlaceType != "admin" {
+		t.Errorf("ContainedWithin = %+v", place.ContainedWithin)
+	}
+}
+
+func TestGeoPlaces(t *testing.T) {
+	var path string
+	var query url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.Query()
+		w.Write([]byte(`{"result": {"places": [` + geoPlaceJSON + `]}}`))
+	})
+	tests := []struct {
+		name  string
+		call  func() (*PlaceList, error)
+		path  string
+		query url.Values
+	}{
+		{
+			"ReverseGeocode",
+			func() (*PlaceList, error) {
+				opts := &GeoSearchOptions{Granularity: "city", MaxResults: 3}
+				return c.Geo.ReverseGeocode(37.78, -122.4, opts.Optionals())
+			},
+			"/geo/reverse_geocode.json",
+			url.Values{"lat": {"37.78"}, "long": {"-122.4"}, "granularity": {"city"}, "max_results": {"3"}},
+		},
+		{
+			"Search",
+			func() (*PlaceList, error) {
+				opts := &GeoSearchOptions{Query: "Twitter HQ", ContainedWithin: "5a110d312052166f"}
+				return c.Geo.Search(opts.Optionals())
+			},
+			"/geo/search.json",
+			url.Values{"query": {"Twitter HQ"}, "contained_within": {"5a110d312052166f"}},
+		},
+	}
+	for _, tt := range tests {
+		places, err := tt.call()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if path != tt.path || query.Encode() != tt.query.Encode() {
+			t.Errorf("%s requested %s?%s, want %s?%s", tt.name, path, query.Encode(), tt.path, tt.query.Encode())
+		}
+		if len(*places) != 1 || len((*places)[0].ContainedWithin) != 1 {
+			t.Errorf("%s: places = %+v", tt.name, places)
+		}
+	}
+}
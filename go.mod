@@ -0,0 +1,8 @@
+module gopkg.in/tweetlib.v2
+
+go 1.23
+
+require (
+	golang.org/x/text v0.14.0
+	gopkg.in/yaml.v2 v2.4.0
+)
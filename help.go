@@ -44,10 +44,15 @@ func (hs *HelpService) Tos() (string, error) {
 	return ret.Text, err
 }
 
-// Returns current Twitter's rate limits
+// Returns current Twitter's rate limits. If resource families (e.g.
+// "statuses", "friends") are given, only their limits are returned.
 // See https://dev.twitter.com/docs/api/1.1/get/application/rate_limit_status
-func (hs *HelpService) Limits() (limits *Limits, err error) {
+func (hs *HelpService) Limits(resources ...string) (limits *Limits, err error) {
+	opts := NewOptionals()
+	if len(resources) > 0 {
+		opts.Set("resources", resources)
+	}
 	limits = &Limits{}
-	err = hs.Call("GET", "application/rate_limit_status", nil, limits)
+	err = hs.Call("GET", "application/rate_limit_status", opts, limits)
 	return
 }
@@ -0,0 +1,109 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Identifier of a tweet, user, direct message, list, etc. It decodes from
+// both the numeric (e.g. "id") and string (e.g. "id_str") forms Twitter
+// uses in its JSON and is always encoded as a number.
+//
+// Identifiers generated since November 2010 are "snowflakes", that embed
+// the time they were created at along with the worker that generated them.
+// See https://dev.twitter.com/overview/api/twitter-ids-json-and-snowflake
+type ID int64
+
+// Unix time, in milliseconds, of the first snowflake
+const snowflakeEpoch = 1288834974657
+
+// Layout of a snowflake: 41 bits of time, 5 bits of datacenter, 5 bits of
+// worker and 12 bits of sequence
+const (
+	sequenceBits   = 12
+	workerBits     = 5
+	datacenterBits = 5
+	timeShift      = sequenceBits + workerBits + datacenterBits
+)
+
+// Parses an identifier in its decimal form
+func ParseID(s string) (ID, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	return ID(id), err
+}
+
+// Returns the smallest snowflake that can be generated at time t. Since
+// snowflakes grow with time, it can be used as since_id or max_id to
+// restrict timelines and searches to a date range.
+func IDFromTime(t time.Time) ID {
+	ms := t.UnixNano()/int64(time.Millisecond) - snowflakeEpoch
+	if ms < 0 {
+		return 0
+	}
+	return ID(ms << timeShift)
+}
+
+// Returns the decimal form of the identifier
+func (id ID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// Returns the time a snowflake was created at, with millisecond precision.
+// The result is meaningless for identifiers that are not snowflakes.
+func (id ID) Time() time.Time {
+	ms := int64(id)>>timeShift + snowflakeEpoch
+	return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond))
+}
+
+// Returns the datacenter that generated a snowflake
+func (id ID) Datacenter() int {
+	return int(id>>(sequenceBits+workerBits)) & (1<<datacenterBits - 1)
+}
+
+// Returns the worker that generated a snowflake within its datacenter
+func (id ID) Worker() int {
+	return int(id>>sequenceBits) & (1<<workerBits - 1)
+}
+
+// Returns the sequence number of a snowflake, which tells apart the
+// snowflakes generated by a worker within the same millisecond
+func (id ID) Sequence() int {
+	return int(id) & (1<<sequenceBits - 1)
+}
+
+// UnmarshalJSON decodes an identifier given either as a number or as a
+// string holding its decimal form.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	s := string(data)
+	switch {
+	case s == "null":
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			*id = 0
+			return nil
+		}
+	}
+	v, err := ParseID(s)
+	if err != nil {
+		return &json.UnmarshalTypeError{Value: jsonKind(data), Type: reflect.TypeOf(*id)}
+	}
+	*id = v
+	return nil
+}
+
+// A list of ids
+type IDList []ID
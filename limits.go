@@ -0,0 +1,149 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"sort"
+	"strings"
+)
+
+// Represents Twitter's current resource limits
+// See https://dev.twitter.com/docs/rate-limiting/1.1/limits
+// Usage:
+//
+//	limits, _ := c.Help.Limits("statuses")
+//	if l, ok := limits.For("statuses/user_timeline"); ok {
+//		fmt.Printf("App has %d user_timeline calls remaining\n", l.Remaining)
+//	}
+type Limits struct {
+	// For which context these limits are
+	// For tweetlib this will always be the user token
+	Context struct {
+		AccessToken string `json:"access_token"`
+	} `json:"rate_limit_context"`
+
+	// Resource families are "account", "help", etc
+	ResourceFamilies map[string]LimitResourceFamily `json:"resources"`
+}
+
+// Limits of the endpoints of a resource family, keyed by endpoint path
+// (e.g. "/statuses/show/:id")
+type LimitResourceFamily map[string]Limit
+
+// Rate limit of an endpoint
+type Limit struct {
+	// How many calls remaining for this resource
+	Remaining int `json:"remaining"`
+	// When the limit will reset
+	Reset Time `json:"reset"`
+	// Total number of calls allowed
+	Limit int `json:"limit"`
+}
+
+// Reports whether no call to the endpoint is allowed until Reset
+func (l Limit) Exhausted() bool {
+	return l.Remaining <= 0
+}
+
+// Rate limit of an endpoint along with where it belongs
+type EndpointLimit struct {
+	// Resource family of the endpoint, e.g. "statuses"
+	Family string
+	// Path of the endpoint, e.g. "/statuses/show/:id"
+	Endpoint string
+	Limit
+}
+
+// Returns the limit of an endpoint, given either by its path as listed in
+// ResourceFamilies (e.g. "statuses/show/:id") or by the path it is called
+// with (e.g. "statuses/show/20"). The leading slash and the ".json" suffix
+// are optional.
+func (l *Limits) For(endpoint string) (limit Limit, ok bool) {
+	path := "/" + strings.TrimSuffix(strings.Trim(endpoint, "/"), ".json")
+	family := strings.SplitN(path[1:], "/", 2)[0]
+	if limit, ok = l.ResourceFamilies[family][path]; ok {
+		return
+	}
+	// the endpoint may belong to another family (e.g. "/friendships/list"
+	// is in "friends") or contain parameters
+	for _, f := range l.families(family) {
+		for _, e := range sortedLimitKeys(l.ResourceFamilies[f]) {
+			if matchEndpoint(e, path) {
+				return l.ResourceFamilies[f][e], true
+			}
+		}
+	}
+	return
+}
+
+// Returns the names of the resource families, starting with first
+func (l *Limits) families(first string) []string {
+	names := make([]string, 0, len(l.ResourceFamilies))
+	for f := range l.ResourceFamilies {
+		if f != first {
+			names = append(names, f)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := l.ResourceFamilies[first]; ok {
+		names = append([]string{first}, names...)
+	}
+	return names
+}
+
+func sortedLimitKeys(f LimitResourceFamily) []string {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Reports whether path matches pattern, where segments of pattern that
+// start with ":" match any segment
+func matchEndpoint(pattern, path string) bool {
+	ps, ss := strings.Split(pattern, "/"), strings.Split(path, "/")
+	if len(ps) != len(ss) {
+		return false
+	}
+	for i := range ps {
+		if ps[i] != ss[i] && !(strings.HasPrefix(ps[i], ":") && ss[i] != "") {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the endpoints that cannot be called until their limit resets,
+// the ones resetting first coming first
+func (l *Limits) Exhausted() (limits []EndpointLimit) {
+	for f, family := range l.ResourceFamilies {
+		for e, limit := range family {
+			if limit.Exhausted() {
+				limits = append(limits, EndpointLimit{f, e, limit})
+			}
+		}
+	}
+	sort.Slice(limits, func(i, j int) bool {
+		a, b := limits[i], limits[j]
+		if !a.Reset.Equal(b.Reset.Time) {
+			return a.Reset.Before(b.Reset.Time)
+		}
+		return a.Endpoint < b.Endpoint
+	})
+	return
+}
+
+// Returns the exhausted endpoint whose limit resets first, that is, the
+// next one that can be called again, or nil if no endpoint is exhausted
+func (l *Limits) Soonest() *EndpointLimit {
+	if limits := l.Exhausted(); len(limits) > 0 {
+		return &limits[0]
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const limitsJSON = `{
+	"rate_limit_context": {"access_token": "1-abc"},
+	"resources": {
+		"statuses": {
+			"/statuses/show/:id": {"limit": 900, "remaining": 899, "reset": 1403602426},
+			"/statuses/user_timeline": {"limit": 900, "remaining": 0, "reset": 1403602500},
+			"/statuses/retweets/:id": {"limit": 75, "remaining": 0, "reset": 1403602400}
+		},
+		"friends": {
+			"/friends/ids": {"limit": 15, "remaining": 15, "reset": 1403602426},
+			"/friendships/list": {"limit": 15, "remaining": 3, "reset": 1403602426}
+		},
+		"lists": {
+			"/lists/show": {"limit": 75, "remaining": 0, "reset": 1403602400}
+		}
+	}
+}`
+
+func loadLimits(t *testing.T) *Limits {
+	limits := &Limits{}
+	if err := json.Unmarshal([]byte(limitsJSON), limits); err != nil {
+		t.Fatal(err)
+	}
+	return limits
+}
+
+func TestLimitsFor(t *testing.T) {
+	limits := loadLimits(t)
+	tests := []struct {
+		endpoint  string
+		ok        bool
+		limit     int
+		remaining int
+	}{
+		{"statuses/show/:id", true, 900, 899},
+		{"/statuses/show/:id", true, 900, 899},
+		{"statuses/show/20", true, 900, 899},
+		{"statuses/show/20.json", true, 900, 899},
+		{"statuses/user_timeline.json", true, 900, 0},
+		{"statuses/retweets/20", true, 75, 0},
+		// listed under another family
+		{"friendships/list", true, 15, 3},
+		{"statuses/show", false, 0, 0},
+		{"statuses/show/20/more", false, 0, 0},
+		{"statuses/lookup", false, 0, 0},
+		{"search/tweets", false, 0, 0},
+	}
+	for _, tt := range tests {
+		l, ok := limits.For(tt.endpoint)
+		if ok != tt.ok || l.Limit != tt.limit || l.Remaining != tt.remaining {
+			t.Errorf("For(%q) = %+v, %v; want limit %d, remaining %d, %v",
+				tt.endpoint, l, ok, tt.limit, tt.remaining, tt.ok)
+		}
+	}
+}
+
+func TestLimitsExhausted(t *testing.T) {
+	limits := loadLimits(t)
+	got := limits.Exhausted()
+	// ordered by reset time, then by endpoint
+	want := []string{"/lists/show", "/statuses/retweets/:id", "/statuses/user_timeline"}
+	if len(got) != len(want) {
+		t.Fatalf("Exhausted() = %+v, want %v", got, want)
+	}
+	for i, e := range got {
+		if e.Endpoint != want[i] || !e.Exhausted() {
+			t.Errorf("Exhausted()[%d] = %+v, want %s", i, e, want[i])
+		}
+	}
+	if got[0].Family != "lists" || got[2].Family != "statuses" {
+		t.Errorf("Exhausted() families = %q, %q", got[0].Family, got[2].Family)
+	}
+
+	soonest := limits.Soonest()
+	if soonest == nil || soonest.Endpoint != "/lists/show" || soonest.Reset.Unix() != 1403602400 {
+		t.Errorf("Soonest() = %+v", soonest)
+	}
+	if s := (&Limits{}).Soonest(); s != nil {
+		t.Errorf("Soonest() without exhausted limits = %+v, want nil", s)
+	}
+}
@@ -6,21 +6,377 @@
 
 package tweetlib
 
+import "fmt"
+
 type ListService struct {
 	*Client
 }
 
+// Maximum number of users that can be added to or removed from a list in
+// a single call
+const maxListMembersPerCall = 100
+
+// Identifies a list, either by its id or by its slug along with its owner
+type ListRef struct {
+	Id              ID
+	Slug            string
+	OwnerScreenName string
+	OwnerId         ID
+}
+
+// Refers to the list with the given id
+func ListByID(id ID) ListRef {
+	return ListRef{Id: id}
+}
+
+// Refers to the list with the given slug (e.g. "team") owned by the user
+// with the given screen name
+func ListBySlug(slug, ownerScreenName string) ListRef {
+	return ListRef{Slug: slug, OwnerScreenName: ownerScreenName}
+}
+
+// Returns a reference to the list
+func (l *List) Ref() ListRef {
+	return ListRef{Id: l.Id}
+}
+
+func (r ListRef) set(opts *Optionals) {
+	if r.Id != 0 {
+		opts.Set("list_id", r.Id)
+		return
+	}
+	opts.setString("slug", r.Slug)
+	opts.setString("owner_screen_name", r.OwnerScreenName)
+	opts.setID("owner_id", r.OwnerId)
+}
+
+// A page of a cursored collection of lists
+type ListCursor struct {
+	CursorPosition
+	Lists ListList `json:"lists"`
+}
+
+// Options of GetAll
+type ListsOptions struct {
+	// Return the user's own lists first
+	Reverse bool
+}
+
+// Returns the optionals to pass to GetAll
+func (lo *ListsOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if lo == nil {
+		return opts
+	}
+	opts.setBool("reverse", lo.Reverse)
+	return opts
+}
+
 // Returns all lists the authenticating or specified user subscribes to,
 // including their own.
 // See https://dev.twitter.com/docs/api/1.1/get/lists/list
 func (ls *ListService) GetAll(screenName string, opts *Optionals) (lists *ListList, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	opts.Add("screen_name", screenName)
+	opts = opts.Clone()
+	opts.setUser(screenName, 0)
 	lists = &ListList{}
 	err = ls.Call("GET", "lists/list", opts, lists)
 	return
 }
 
-// https://dev.twitter.com/docs/api/1.1/get/lists/statuses
+// Options of Create and Update
+type ListOptions struct {
+	// New name of the list. Only supported by Update.
+	Name string
+	// Either "public" or "private"
+	Mode        string
+	Description string
+}
+
+// Returns the optionals to pass to Create and Update
+func (lo *ListOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if lo == nil {
+		return opts
+	}
+	opts.setString("name", lo.Name)
+	opts.setString("mode", lo.Mode)
+	opts.setString("description", lo.Description)
+	return opts
+}
+
+// Returns the specified list. Private lists are only shown if the
+// authenticating user owns them.
+// See https://dev.twitter.com/docs/api/1.1/get/lists/show
+func (ls *ListService) Show(list ListRef, opts *Optionals) (l *List, err error) {
+	opts = opts.Clone()
+	list.set(opts)
+	l = &List{}
+	err = ls.Call("GET", "lists/show", opts, l)
+	return
+}
+
+// Returns a timeline of tweets authored by members of the specified list.
+// It takes the same options as the other timelines, see TimelineOptions.
+// See https://dev.twitter.com/docs/api/1.1/get/lists/statuses
+func (ls *ListService) Statuses(list ListRef, opts *Optionals) (tweets *TweetList, err error) {
+	opts = opts.Clone()
+	list.set(opts)
+	tweets = &TweetList{}
+	err = ls.Call("GET", "lists/statuses", opts, tweets)
+	return
+}
+
+// Calls fn with every page of the timeline of a list, newest first, until
+// the whole timeline has been returned or fn returns an error. Return
+// ErrStopPaging from fn to stop paging early. See Statuses.
+func (ls *ListService) StatusesPages(list ListRef, opts *Optionals, fn func(TweetList) error) error {
+	return maxIDPages(opts, func(opts *Optionals) (TweetList, error) {
+		tweets, err := ls.Statuses(list, opts)
+		if err != nil {
+			return nil, err
+		}
+		return *tweets, nil
+	}, fn)
+}
+
+// Creates a new list for the authenticating user. Lists are public unless
+// the "mode" optional parameter is set to "private", see ListOptions.
+// See https://dev.twitter.com/docs/api/1.1/post/lists/create
+func (ls *ListService) Create(name string, opts *Optionals) (l *List, err error) {
+	opts = opts.Clone()
+	opts.Set("name", name)
+	l = &List{}
+	err = ls.Call("POST", "lists/create", opts, l)
+	return
+}
+
+// Updates the name, mode or description of a list owned by the
+// authenticating user, see ListOptions.
+// See https://dev.twitter.com/docs/api/1.1/post/lists/update
+func (ls *ListService) Update(list ListRef, opts *Optionals) (l *List, err error) {
+	opts = opts.Clone()
+	list.set(opts)
+	l = &List{}
+	err = ls.Call("POST", "lists/update", opts, l)
+	return
+}
+
+// Deletes a list owned by the authenticating user. Returns the deleted
+// list.
+// See https://dev.twitter.com/docs/api/1.1/post/lists/destroy
+func (ls *ListService) Destroy(list ListRef, opts *Optionals) (l *List, err error) {
+	opts = opts.Clone()
+	list.set(opts)
+	l = &List{}
+	err = ls.Call("POST", "lists/destroy", opts, l)
+	return
+}
+
+// Returns the page of the members of a list at cursor, from -1 for the
+// first page, or use MembersPages.
+// See https://dev.twitter.com/docs/api/1.1/get/lists/members
+func (ls *ListService) Members(list ListRef, cursor int64, opts *Optionals) (users *UserCursor, err error) {
+	opts = opts.Clone()
+	list.set(opts)
+	opts.setCursor(cursor)
+	users = &UserCursor{}
+	err = ls.Call("GET", "lists/members", opts, users)
+	return
+}
+
+// Calls fn with every page of the members of a list until all of them have
+// been returned or fn returns an error. Return ErrStopPaging from fn to
+// stop paging early.
+func (ls *ListService) MembersPages(list ListRef, opts *Optionals, fn func([]User) error) error {
+	return cursorPages(opts, func(opts *Optionals) (*CursorPosition, error) {
+		users, err := ls.Members(list, 0, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &users.CursorPosition, fn(users.Users)
+	})
+}
+
+// Returns the user if they are a member of the list. Twitter replies with
+// an error otherwise.
+// See https://dev.twitter.com/docs/api/1.1/get/lists/members/show
+func (ls *ListService) Member(list ListRef, screenName string, userID ID, opts *Optionals) (user *User, err error) {
+	opts = opts.Clone()
+	list.set(opts)
+	opts.setUser(screenName, userID)
+	user = &User{}
+	err = ls.Call("GET", "lists/members/show", opts, user)
+	return
+}
+
+// Adds a user to a list owned by the authenticating user
+// See https://dev.twitter.com/docs/api/1.1/post/lists/members/create
+func (ls *ListService) AddMember(list ListRef, screenName string, userID ID, opts *Optionals) (l *List, err error) {
+	opts = opts.Clone()
+	list.set(opts)
+	opts.setUser(screenName, userID)
+	l = &List{}
+	err = ls.Call("POST", "lists/members/create", opts, l)
+	return
+}
+
+// Adds users, given by screen name or by id, to a list owned by the
+// authenticating user. Twitter accepts up to 100 users per call, so larger
+// batches are sent in several calls. Returns the list as updated by the
+// last call.
+// See https://dev.twitter.com/docs/api/1.1/post/lists/members/create_all
+func (ls *ListService) AddMembers(list ListRef, screenNames []string, userIDs []ID, opts *Optionals) (l *List, err error) {
+	return ls.batchMembers("lists/members/create_all", list, screenNames, userIDs, opts)
+}
+
+// Removes a user from a list owned by the authenticating user
+// See https://dev.twitter.com/docs/api/1.1/post/lists/members/destroy
+func (ls *ListService) RemoveMember(list ListRef, screenName string, userID ID, opts *Optionals) (l *List, err error) {
+	opts = opts.Clone()
+	list.set(opts)
+	opts.setUser(screenName, userID)
+	l = &List{}
+	err = ls.Call("POST", "lists/members/destroy", opts, l)
+	return
+}
+
+// Removes users, given by screen name or by id, from a list owned by the
+// authenticating user, in batches of up to 100 users. See AddMembers.
+// See https://dev.twitter.com/docs/api/1.1/post/lists/members/destroy_all
+func (ls *ListService) RemoveMembers(list ListRef, screenNames []string, userIDs []ID, opts *Optionals) (l *List, err error) {
+	return ls.batchMembers("lists/members/destroy_all", list, screenNames, userIDs, opts)
+}
+
+// Calls endpoint with the given users, at most maxListMembersPerCall at a
+// time
+func (ls *ListService) batchMembers(endpoint string, list ListRef, screenNames []string, userIDs []ID, opts *Optionals) (l *List, err error) {
+	if len(screenNames) == 0 && len(userIDs) == 0 {
+		return nil, fmt.Errorf("no users given; lists take up to %d users per call", maxListMembersPerCall)
+	}
+	call := func(param string, users interface{}) error {
+		o := opts.Clone()
+		list.set(o)
+		o.Set(param, users)
+		l = &List{}
+		return ls.Call("POST", endpoint, o, l)
+	}
+	if err = userBatches(screenNames, userIDs, maxListMembersPerCall, call); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Returns the page of the subscribers of a list at cursor, from -1 for the
+// first page, or use SubscribersPages.
+// See https://dev.twitter.com/docs/api/1.1/get/lists/subscribers
+func (ls *ListService) Subscribers(list ListRef, cursor int64, opts *Optionals) (users *UserCursor, err error) {
+	opts = opts.Clone()
+	list.set(opts)
+	opts.setCursor(cursor)
+	users = &UserCursor{}
+	err = ls.Call("GET", "lists/subscribers", opts, users)
+	return
+}
+
+// Calls fn with every page of the subscribers of a list until all of them
+// have been returned or fn returns an error. Return ErrStopPaging from fn
+// to stop paging early.
+func (ls *ListService) SubscribersPages(list ListRef, opts *Optionals, fn func([]User) error) error {
+	return cursorPages(opts, func(opts *Optionals) (*CursorPosition, error) {
+		users, err := ls.Subscribers(list, 0, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &users.CursorPosition, fn(users.Users)
+	})
+}
+
+// Returns the user if they subscribe to the list. Twitter replies with an
+// error otherwise.
+// See https://dev.twitter.com/docs/api/1.1/get/lists/subscribers/show
+func (ls *ListService) Subscriber(list ListRef, screenName string, userID ID, opts *Optionals) (user *User, err error) {
+	opts = opts.Clone()
+	list.set(opts)
+	opts.setUser(screenName, userID)
+	user = &User{}
+	err = ls.Call("GET", "lists/subscribers/show", opts, user)
+	return
+}
+
+// Subscribes the authenticating user to a list
+// See https://dev.twitter.com/docs/api/1.1/post/lists/subscribers/create
+func (ls *ListService) Subscribe(list ListRef, opts *Optionals) (l *List, err error) {
+	opts = opts.Clone()
+	list.set(opts)
+	l = &List{}
+	err = ls.Call("POST", "lists/subscribers/create", opts, l)
+	return
+}
+
+// Unsubscribes the authenticating user from a list
+// See https://dev.twitter.com/docs/api/1.1/post/lists/subscribers/destroy
+func (ls *ListService) Unsubscribe(list ListRef, opts *Optionals) (l *List, err error) {
+	opts = opts.Clone()
+	list.set(opts)
+	l = &List{}
+	err = ls.Call("POST", "lists/subscribers/destroy", opts, l)
+	return
+}
+
+// Returns the page of the lists the user has been added to at cursor, from
+// -1 for the first page. Pass "filter_to_owned_lists" to only get the
+// lists owned by the authenticating user.
+// See https://dev.twitter.com/docs/api/1.1/get/lists/memberships
+func (ls *ListService) Memberships(screenName string, userID ID, cursor int64, opts *Optionals) (lists *ListCursor, err error) {
+	return ls.userLists("lists/memberships", screenName, userID, cursor, opts)
+}
+
+// Calls fn with every page of the lists the user has been added to. See
+// Memberships.
+func (ls *ListService) MembershipsPages(screenName string, userID ID, opts *Optionals, fn func(ListList) error) error {
+	return ls.userListsPages("lists/memberships", screenName, userID, opts, fn)
+}
+
+// Returns the page of the lists owned by the user at cursor, from -1 for
+// the first page
+// See https://dev.twitter.com/docs/api/1.1/get/lists/ownerships
+func (ls *ListService) Ownerships(screenName string, userID ID, cursor int64, opts *Optionals) (lists *ListCursor, err error) {
+	return ls.userLists("lists/ownerships", screenName, userID, cursor, opts)
+}
+
+// Calls fn with every page of the lists owned by the user. See Ownerships.
+func (ls *ListService) OwnershipsPages(screenName string, userID ID, opts *Optionals, fn func(ListList) error) error {
+	return ls.userListsPages("lists/ownerships", screenName, userID, opts, fn)
+}
+
+// Returns the page of the lists the user subscribes to at cursor, from -1
+// for the first page, not including their own
+// See https://dev.twitter.com/docs/api/1.1/get/lists/subscriptions
+func (ls *ListService) Subscriptions(screenName string, userID ID, cursor int64, opts *Optionals) (lists *ListCursor, err error) {
+	return ls.userLists("lists/subscriptions", screenName, userID, cursor, opts)
+}
+
+// Calls fn with every page of the lists the user subscribes to. See
+// Subscriptions.
+func (ls *ListService) SubscriptionsPages(screenName string, userID ID, opts *Optionals, fn func(ListList) error) error {
+	return ls.userListsPages("lists/subscriptions", screenName, userID, opts, fn)
+}
+
+func (ls *ListService) userLists(endpoint, screenName string, userID ID, cursor int64, opts *Optionals) (lists *ListCursor, err error) {
+	opts = opts.Clone()
+	opts.setUser(screenName, userID)
+	opts.setCursor(cursor)
+	lists = &ListCursor{}
+	err = ls.Call("GET", endpoint, opts, lists)
+	return
+}
+
+func (ls *ListService) userListsPages(endpoint, screenName string, userID ID, opts *Optionals, fn func(ListList) error) error {
+	return cursorPages(opts, func(opts *Optionals) (*CursorPosition, error) {
+		lists, err := ls.userLists(endpoint, screenName, userID, 0, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &lists.CursorPosition, fn(lists.Lists)
+	})
+}
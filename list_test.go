@@ -0,0 +1,136 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestListRef(t *testing.T) {
+	tests := []struct {
+		ref  ListRef
+		want string
+	}{
+		{ListByID(42), "list_id=42"},
+		{ListBySlug("team", "golang"), "owner_screen_name=golang&slug=team"},
+		{ListRef{Slug: "team", OwnerId: 7}, "owner_id=7&slug=team"},
+		{(&List{Id: 42, Slug: "team"}).Ref(), "list_id=42"},
+	}
+	for _, tt := range tests {
+		opts := NewOptionals()
+		tt.ref.set(opts)
+		if got := opts.Values.Encode(); got != tt.want {
+			t.Errorf("%+v sets %s, want %s", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestAddMembersBatches(t *testing.T) {
+	var calls []url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/lists/members/create_all.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		r.ParseForm()
+		calls = append(calls, r.PostForm)
+		fmt.Fprintf(w, `{"id": 42, "member_count": %d}`, len(calls))
+	})
+
+	names := []string{"a", "b", "c"}
+	ids := make([]ID, 250)
+	for i := range ids {
+		ids[i] = ID(i + 1)
+	}
+	l, err := c.Lists.AddMembers(ListByID(42), names, ids, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// screen names and ids are sent separately, at most 100 at a time
+	want := []struct {
+		param       string
+		first, last string
+		count       int
+	}{
+		{"screen_name", "a", "c", 3},
+		{"user_id", "1", "100", 100},
+		{"user_id", "101", "200", 100},
+		{"user_id", "201", "250", 50},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("made %d calls, want %d", len(calls), len(want))
+	}
+	for i, w := range want {
+		users := strings.Split(calls[i].Get(w.param), ",")
+		if len(users) != w.count || users[0] != w.first || users[len(users)-1] != w.last {
+			t.Errorf("call %d sent %s=%s...%s (%d), want %s...%s (%d)",
+				i, w.param, users[0], users[len(users)-1], len(users), w.first, w.last, w.count)
+		}
+		if calls[i].Get("list_id") != "42" {
+			t.Errorf("call %d does not name the list: %v", i, calls[i])
+		}
+	}
+	// the list returned is the one of the last call
+	if l.MemberCount != 4 {
+		t.Errorf("returned list with member_count %d, want the last one", l.MemberCount)
+	}
+
+	if _, err := c.Lists.RemoveMembers(ListByID(42), nil, nil, nil); err == nil || !strings.Contains(err.Error(), "100") {
+		t.Errorf("RemoveMembers without users = %v, want an error naming the limit", err)
+	}
+}
+
+func TestListCursors(t *testing.T) {
+	users := []string{`[{"id": 1}]`, `[{"id": 2}]`}
+	lists := []string{`[{"id": 11}]`, `[{"id": 12}]`}
+	tests := []struct {
+		path  string
+		key   string
+		pages []string
+		want  [2]ID
+		get   func(c *Client, cursor int64) (ID, *CursorPosition, error)
+	}{
+		{"/lists/members.json", "users", users, [2]ID{1, 2}, func(c *Client, cursor int64) (ID, *CursorPosition, error) {
+			res, err := c.Lists.Members(ListByID(42), cursor, nil)
+			return res.Users[0].Id, &res.CursorPosition, err
+		}},
+		{"/lists/subscribers.json", "users", users, [2]ID{1, 2}, func(c *Client, cursor int64) (ID, *CursorPosition, error) {
+			res, err := c.Lists.Subscribers(ListByID(42), cursor, nil)
+			return res.Users[0].Id, &res.CursorPosition, err
+		}},
+		{"/lists/memberships.json", "lists", lists, [2]ID{11, 12}, func(c *Client, cursor int64) (ID, *CursorPosition, error) {
+			res, err := c.Lists.Memberships("golang", 0, cursor, nil)
+			return res.Lists[0].Id, &res.CursorPosition, err
+		}},
+		{"/lists/ownerships.json", "lists", lists, [2]ID{11, 12}, func(c *Client, cursor int64) (ID, *CursorPosition, error) {
+			res, err := c.Lists.Ownerships("golang", 0, cursor, nil)
+			return res.Lists[0].Id, &res.CursorPosition, err
+		}},
+		{"/lists/subscriptions.json", "lists", lists, [2]ID{11, 12}, func(c *Client, cursor int64) (ID, *CursorPosition, error) {
+			res, err := c.Lists.Subscriptions("golang", 0, cursor, nil)
+			return res.Lists[0].Id, &res.CursorPosition, err
+		}},
+	}
+	for _, tt := range tests {
+		var queries []url.Values
+		c := cursorServer(t, tt.path, tt.key, tt.pages, &queries)
+		first, pos, err := tt.get(c, -1)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		second, _, err := tt.get(c, pos.Next)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if [2]ID{first, second} != tt.want || fmt.Sprint(cursors(queries)) != "[-1 1]" {
+			t.Errorf("%s: got %d, %d with cursors %q, want %v with [-1 1]", tt.path, first, second, cursors(queries), tt.want)
+		}
+	}
+}
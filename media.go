@@ -0,0 +1,420 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Groups functions to upload images, GIFs and videos. Uploaded media is
+// attached to tweets by passing its id in UpdateOptions.MediaIds.
+type MediaService struct {
+	*Client
+}
+
+// Categories of media, telling Twitter what the media will be used for
+// so it processes it accordingly. Videos and animated GIFs must be given
+// one to be uploaded in chunks.
+const (
+	MediaCategoryTweetImage = "tweet_image"
+	MediaCategoryTweetGif   = "tweet_gif"
+	MediaCategoryTweetVideo = "tweet_video"
+	MediaCategoryDMImage    = "dm_image"
+	MediaCategoryDMGif      = "dm_gif"
+	MediaCategoryDMVideo    = "dm_video"
+)
+
+// Size of the segments ChunkedUpload sends when no ChunkSize is given.
+// Twitter takes segments of up to 5MB.
+const DefaultChunkSize = 1 << 20
+
+// Media uploaded to Twitter
+type Media struct {
+	MediaId       ID     `json:"media_id"`
+	MediaIdString string `json:"media_id_string"`
+	// Size of the media in bytes
+	Size int64 `json:"size"`
+	// Number of seconds the media can be attached to a tweet for
+	ExpiresAfterSecs int `json:"expires_after_secs"`
+	// Set for images
+	Image *UploadedImage `json:"image"`
+	// Set for videos and GIFs
+	Video *UploadedVideo `json:"video"`
+	// Set when the media is processed after being uploaded, until the
+	// processing is over
+	ProcessingInfo *ProcessingInfo `json:"processing_info"`
+}
+
+// Details of an uploaded image
+type UploadedImage struct {
+	ImageType string `json:"image_type"`
+	Width     int    `json:"w"`
+	Height    int    `json:"h"`
+}
+
+// Details of an uploaded video
+type UploadedVideo struct {
+	VideoType string `json:"video_type"`
+}
+
+// States of the processing of uploaded media
+const (
+	ProcessingPending    = "pending"
+	ProcessingInProgress = "in_progress"
+	ProcessingSucceeded  = "succeeded"
+	ProcessingFailed     = "failed"
+)
+
+// State of the processing of uploaded media
+type ProcessingInfo struct {
+	// One of the Processing constants
+	State string `json:"state"`
+	// Number of seconds to wait before checking the state again
+	CheckAfterSecs int `json:"check_after_secs"`
+	// Estimated progress of the processing, from 0 to 100
+	ProgressPercent int `json:"progress_percent"`
+	// Why the processing failed
+	Error *ProcessingError `json:"error"`
+}
+
+// Reason given by Twitter for the failure of the processing of media
+type ProcessingError struct {
+	Code    int    `json:"code"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// Error returned when Twitter fails to process uploaded media, e.g. because
+// a video is too long or its format is not supported
+type MediaProcessingError struct {
+	MediaId ID
+	ProcessingError
+}
+
+func (e *MediaProcessingError) Error() string {
+	if e.Name == "" && e.Message == "" {
+		return fmt.Sprintf("processing of media %d failed", e.MediaId)
+	}
+	return fmt.Sprintf("processing of media %d failed: %s: %s (%d)", e.MediaId, e.Name, e.Message, e.Code)
+}
+
+// Options of media uploads
+type UploadOptions struct {
+	// What the media will be used for: one of the MediaCategory constants
+	MediaCategory string
+	// Users allowed to attach the media to their tweets, besides the
+	// uploader
+	AdditionalOwners []ID
+	// Whether the media can be attached to direct messages sent to
+	// several users
+	Shared bool
+}
+
+// Returns the optionals to pass to Media.Upload
+func (uo *UploadOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if uo == nil {
+		return opts
+	}
+	opts.setString("media_category", uo.MediaCategory)
+	opts.setIDs("additional_owners", uo.AdditionalOwners)
+	opts.setBool("shared", uo.Shared)
+	return opts
+}
+
+// Options of Media.ChunkedUpload
+type ChunkedUploadOptions struct {
+	UploadOptions
+	// Size of the segments the media is sent in. Defaults to
+	// DefaultChunkSize.
+	ChunkSize int
+	// If not nil, it is called after each segment is sent with the number
+	// of bytes sent so far and the size of the media
+	Progress func(sent, total int64)
+	// Id of the media and index of the segment to resume an upload from,
+	// as given by the *UploadError it failed with. ChunkSize must then be
+	// the one of the error too, as the segment is counted in chunks.
+	MediaId ID
+	Segment int
+	// If not nil, UploadAndWait calls it with the progress percentage of
+	// the processing of the media each time it checks it
+	ProcessingProgress func(percent int)
+}
+
+// Error returned by Media.ChunkedUpload when a segment could not be sent.
+// The upload can be resumed by calling ChunkedUpload again with MediaId
+// and Segment set to the ones of the error and a reader positioned at
+// Offset. The chunk size has to stay ChunkSize for Segment to point at
+// the same position.
+type UploadError struct {
+	MediaId ID
+	// Index of the segment that failed and its position in the media
+	Segment int
+	Offset  int64
+	// Size of the segments the media was being sent in
+	ChunkSize int
+	Err       error
+}
+
+func (e *UploadError) Error() string {
+	return fmt.Sprintf("upload of media %d failed at segment %d: %v", e.MediaId, e.Segment, e.Err)
+}
+
+// Uploads an image in a single request. Images can be up to 5MB, and
+// GIFs and videos have to be sent with ChunkedUpload.
+// See https://dev.twitter.com/rest/reference/post/media/upload
+func (ms *MediaService) Upload(media io.Reader, opts *Optionals) (m *Media, err error) {
+	m = &Media{}
+	err = ms.upload("POST", opts, media, m)
+	return
+}
+
+// Uploads media of totalBytes bytes and of the given MIME type (e.g.
+// video/mp4) read from r, in segments of opts.ChunkSize bytes. This is
+// required for videos and GIFs, which also need a media category, and
+// works for images as well.
+//
+// Videos and GIFs are processed by Twitter once uploaded, as told by the
+// ProcessingInfo of the returned media, and cannot be attached to tweets
+// before it succeeds.
+//
+// If sending a segment fails, the returned error is an *UploadError
+// telling where to resume the upload from. Its Err is io.ErrUnexpectedEOF
+// if r ends before totalBytes are read.
+// See https://dev.twitter.com/rest/media/uploading-media
+func (ms *MediaService) ChunkedUpload(r io.Reader, totalBytes int64, mediaType string, opts *ChunkedUploadOptions) (m *Media, err error) {
+	if opts == nil {
+		opts = &ChunkedUploadOptions{}
+	}
+	size := opts.ChunkSize
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	mediaID, segment := opts.MediaId, opts.Segment
+	if mediaID == 0 {
+		m, err = ms.Init(totalBytes, mediaType, opts.UploadOptions.Optionals())
+		if err != nil {
+			return nil, err
+		}
+		mediaID, segment = m.MediaId, 0
+	}
+	sent := int64(segment) * int64(size)
+	buf := make([]byte, size)
+	for sent < totalBytes {
+		chunk := buf
+		if left := totalBytes - sent; left < int64(size) {
+			chunk = buf[:left]
+		}
+		n, err := io.ReadFull(r, chunk)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err == nil {
+			err = ms.Append(mediaID, segment, bytes.NewReader(chunk[:n]), nil)
+		}
+		if err != nil {
+			return nil, &UploadError{MediaId: mediaID, Segment: segment, Offset: sent, ChunkSize: size, Err: err}
+		}
+		segment++
+		sent += int64(n)
+		if opts.Progress != nil {
+			opts.Progress(sent, totalBytes)
+		}
+	}
+	return ms.Finalize(mediaID, nil)
+}
+
+// Uploads media in chunks like ChunkedUpload, then waits for Twitter to
+// process it like WaitForProcessing. Once it returns, the media can be
+// attached to tweets, e.g. to post a video:
+//
+//	opts := &tweetlib.ChunkedUploadOptions{}
+//	opts.MediaCategory = tweetlib.MediaCategoryTweetVideo
+//	video, err := client.Media.UploadAndWait(ctx, file, size, "video/mp4", opts)
+//	if err != nil {
+//		return err
+//	}
+//	tweet, err := client.Tweets.Update("Hello, world",
+//		(&tweetlib.UpdateOptions{MediaIds: []tweetlib.ID{video.MediaId}}).Optionals())
+func (ms *MediaService) UploadAndWait(ctx context.Context, r io.Reader, totalBytes int64, mediaType string, opts *ChunkedUploadOptions) (m *Media, err error) {
+	if m, err = ms.ChunkedUpload(r, totalBytes, mediaType, opts); err != nil {
+		return nil, err
+	}
+	if m.ProcessingInfo == nil || m.ProcessingInfo.State == ProcessingSucceeded {
+		return m, nil
+	}
+	var progress func(int)
+	if opts != nil {
+		progress = opts.ProcessingProgress
+	}
+	if err = ms.wait(ctx, m.ProcessingInfo); err != nil {
+		return nil, err
+	}
+	return ms.WaitForProcessing(ctx, m.MediaId, progress)
+}
+
+// Waits for Twitter to process media uploaded in chunks, checking its
+// state as often as Twitter asks to. If progress is not nil, it is called
+// with the progress percentage of the processing each time it is checked.
+//
+// Returns the processed media, or a *MediaProcessingError if Twitter
+// failed to process it. Media that needs no processing is returned at once.
+// Waiting stops with the context's error if it is done first.
+// See https://dev.twitter.com/rest/reference/get/media/upload-status
+func (ms *MediaService) WaitForProcessing(ctx context.Context, mediaID ID, progress func(percent int)) (m *Media, err error) {
+	for {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		if m, err = ms.Status(mediaID, nil); err != nil {
+			return nil, err
+		}
+		info := m.ProcessingInfo
+		if info == nil {
+			return m, nil
+		}
+		switch info.State {
+		case ProcessingFailed:
+			perr := &MediaProcessingError{MediaId: mediaID}
+			if info.Error != nil {
+				perr.ProcessingError = *info.Error
+			}
+			return nil, perr
+		case ProcessingSucceeded:
+			if progress != nil {
+				progress(100)
+			}
+			return m, nil
+		}
+		if progress != nil {
+			progress(info.ProgressPercent)
+		}
+		if err = ms.wait(ctx, info); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Unit of ProcessingInfo.CheckAfterSecs, shortened by tests
+var checkAfterUnit = time.Second
+
+// Waits for as long as Twitter asks to before checking the state of the
+// processing of media again, or until the context is done
+func (ms *MediaService) wait(ctx context.Context, info *ProcessingInfo) error {
+	delay := time.Duration(info.CheckAfterSecs) * checkAfterUnit
+	if delay <= 0 {
+		delay = checkAfterUnit
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Starts a chunked upload of media of totalBytes bytes and of the given
+// MIME type. Returns the media, whose id is used to send its segments.
+// See https://dev.twitter.com/rest/reference/post/media/upload-init
+func (ms *MediaService) Init(totalBytes int64, mediaType string, opts *Optionals) (m *Media, err error) {
+	opts = opts.Clone()
+	opts.Set("command", "INIT")
+	opts.Set("total_bytes", totalBytes)
+	opts.Set("media_type", mediaType)
+	m = &Media{}
+	err = ms.upload("POST", opts, nil, m)
+	return
+}
+
+// Sends the segment of a chunked upload with the given index, from 0.
+// Segments can be sent in any order and again if sending them failed.
+// See https://dev.twitter.com/rest/reference/post/media/upload-append
+func (ms *MediaService) Append(mediaID ID, segment int, data io.Reader, opts *Optionals) error {
+	opts = opts.Clone()
+	opts.Set("command", "APPEND")
+	opts.Set("media_id", mediaID)
+	opts.Set("segment_index", segment)
+	return ms.upload("POST", opts, data, nil)
+}
+
+// Completes a chunked upload once all its segments are sent
+// See https://dev.twitter.com/rest/reference/post/media/upload-finalize
+func (ms *MediaService) Finalize(mediaID ID, opts *Optionals) (m *Media, err error) {
+	opts = opts.Clone()
+	opts.Set("command", "FINALIZE")
+	opts.Set("media_id", mediaID)
+	m = &Media{}
+	err = ms.upload("POST", opts, nil, m)
+	return
+}
+
+// Returns the media of a chunked upload, with the state of its processing
+// See https://dev.twitter.com/rest/reference/get/media/upload-status
+func (ms *MediaService) Status(mediaID ID, opts *Optionals) (m *Media, err error) {
+	opts = opts.Clone()
+	opts.Set("command", "STATUS")
+	opts.Set("media_id", mediaID)
+	m = &Media{}
+	err = ms.upload("GET", opts, nil, m)
+	return
+}
+
+// Makes a call to the upload endpoint (see Client.UploadEndpoint) and unmarshals the result into resp,
+// if there is one. The parameters are sent in a multipart form along with
+// the media if it is not nil, and as in CallJSON otherwise.
+func (ms *MediaService) upload(method string, opts *Optionals, media io.Reader, resp interface{}) (err error) {
+	values := opts.Clone().Values
+	uploadURL := ms.UploadEndpoint + "/media/upload.json"
+	var req *http.Request
+	switch {
+	case media != nil:
+		body := &bytes.Buffer{}
+		mp := multipart.NewWriter(body)
+		for n, v := range values {
+			mp.WriteField(n, v[0])
+		}
+		writer, err := mp.CreateFormFile("media", "media")
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(writer, media); err != nil {
+			return err
+		}
+		mp.Close()
+		req, _ = http.NewRequest("POST", uploadURL, body)
+		req.Header.Set("Content-Type", mp.FormDataContentType())
+	case method == "POST":
+		body := strings.NewReader(values.Encode())
+		req, _ = http.NewRequest(method, uploadURL+"?"+values.Encode(), body)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	default:
+		req, _ = http.NewRequest(method, uploadURL+"?"+values.Encode(), nil)
+	}
+	res, err := ms.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	if err = checkResponse(res); err != nil {
+		return
+	}
+	rawJSON, err := ioutil.ReadAll(res.Body)
+	if err != nil || resp == nil {
+		return
+	}
+	return ms.decode("media/upload", rawJSON, resp)
+}
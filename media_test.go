@@ -0,0 +1,302 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testMediaID ID = 710511363345354753
+
+// Returns a client whose chunked uploads are served by a fake upload
+// endpoint, recording the calls it gets in calls. The first attempt to
+// send the segment with index failSegment fails.
+func uploadServer(t *testing.T, failSegment int, calls *[]string) *Client {
+	failed := false
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/media/upload.json" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		var call string
+		switch cmd := r.FormValue("command"); cmd {
+		case "INIT":
+			call = fmt.Sprintf("INIT %s %s %s", r.FormValue("total_bytes"), r.FormValue("media_type"), r.FormValue("media_category"))
+		case "APPEND":
+			f, _, err := r.FormFile("media")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			data, _ := io.ReadAll(f)
+			call = fmt.Sprintf("APPEND %s %s %s", r.FormValue("media_id"), r.FormValue("segment_index"), data)
+			if r.FormValue("segment_index") == fmt.Sprint(failSegment) && !failed {
+				failed = true
+				*calls = append(*calls, call+" failed")
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"errors": [{"code": 131, "message": "Internal error"}]}`))
+				return
+			}
+		default:
+			call = cmd + " " + r.FormValue("media_id")
+		}
+		*calls = append(*calls, call)
+		if r.FormValue("command") != "APPEND" {
+			fmt.Fprintf(w, `{"media_id": %d, "media_id_string": "%[1]d", "size": 10}`, testMediaID)
+		}
+	})
+}
+
+func TestUpload(t *testing.T) {
+	var category, data string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/media/upload.json" {
+			t.Errorf("requested %s %s", r.Method, r.URL.Path)
+		}
+		f, _, err := r.FormFile("media")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		b, _ := io.ReadAll(f)
+		category, data = r.FormValue("media_category"), string(b)
+		w.Write([]byte(`{"media_id": 710511363345354753, "media_id_string": "710511363345354753",
+			"size": 11065, "expires_after_secs": 86400,
+			"image": {"image_type": "image/jpeg", "w": 800, "h": 320}}`))
+	})
+	opts := &UploadOptions{MediaCategory: MediaCategoryTweetImage}
+	m, err := c.Media.Upload(strings.NewReader("jpeg data"), opts.Optionals())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if category != MediaCategoryTweetImage || data != "jpeg data" {
+		t.Errorf("sent %q with category %q", data, category)
+	}
+	if m.MediaId != testMediaID || m.Size != 11065 || m.Image == nil || m.Image.Width != 800 {
+		t.Errorf("media = %+v", m)
+	}
+}
+
+func TestChunkedUpload(t *testing.T) {
+	var calls []string
+	c := uploadServer(t, -1, &calls)
+	var progress []int64
+	opts := &ChunkedUploadOptions{
+		ChunkSize: 4,
+		Progress:  func(sent, total int64) { progress = append(progress, sent, total) },
+	}
+	opts.MediaCategory = MediaCategoryTweetVideo
+	m, err := c.Media.ChunkedUpload(strings.NewReader("abcdefghij"), 10, "video/mp4", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MediaId != testMediaID {
+		t.Errorf("media id = %d", m.MediaId)
+	}
+	want := []string{
+		"INIT 10 video/mp4 tweet_video",
+		"APPEND 710511363345354753 0 abcd",
+		"APPEND 710511363345354753 1 efgh",
+		"APPEND 710511363345354753 2 ij",
+		"FINALIZE 710511363345354753",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+	if want := []int64{4, 10, 8, 10, 10, 10}; !reflect.DeepEqual(progress, want) {
+		t.Errorf("progress = %v, want %v", progress, want)
+	}
+}
+
+func TestChunkedUploadResume(t *testing.T) {
+	var calls []string
+	c := uploadServer(t, 1, &calls)
+	media := strings.NewReader("abcdefghij")
+	opts := &ChunkedUploadOptions{ChunkSize: 4}
+	_, err := c.Media.ChunkedUpload(media, 10, "image/gif", opts)
+	var uerr *UploadError
+	if !errors.As(err, &uerr) {
+		t.Fatalf("err = %v, want an *UploadError", err)
+	}
+	if uerr.MediaId != testMediaID || uerr.Segment != 1 || uerr.Offset != 4 || uerr.ChunkSize != 4 || uerr.Err == nil {
+		t.Errorf("err = %+v", uerr)
+	}
+	if msg := "upload of media 710511363345354753 failed at segment 1: "; !strings.HasPrefix(uerr.Error(), msg) {
+		t.Errorf("Error() = %q, want prefix %q", uerr.Error(), msg)
+	}
+
+	calls = nil
+	opts.MediaId, opts.Segment = uerr.MediaId, uerr.Segment
+	media.Seek(uerr.Offset, io.SeekStart)
+	if _, err = c.Media.ChunkedUpload(media, 10, "image/gif", opts); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"APPEND 710511363345354753 1 efgh",
+		"APPEND 710511363345354753 2 ij",
+		"FINALIZE 710511363345354753",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("resumed calls = %q, want %q", calls, want)
+	}
+}
+
+func TestChunkedUploadShortReader(t *testing.T) {
+	var calls []string
+	c := uploadServer(t, -1, &calls)
+	opts := &ChunkedUploadOptions{ChunkSize: 4}
+	_, err := c.Media.ChunkedUpload(strings.NewReader("abcdef"), 10, "video/mp4", opts)
+	var uerr *UploadError
+	if !errors.As(err, &uerr) {
+		t.Fatalf("err = %v, want an *UploadError", err)
+	}
+	if uerr.Segment != 1 || uerr.Offset != 4 || uerr.Err != io.ErrUnexpectedEOF {
+		t.Errorf("err = %+v", uerr)
+	}
+	want := []string{
+		"INIT 10 video/mp4 ",
+		"APPEND 710511363345354753 0 abcd",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+}
+
+// Returns a client whose uploads are processed with the given states, as
+// JSON processing infos: finalizing an upload or checking its status
+// returns the next one. The times statuses are checked at are recorded
+// in checks.
+func processingServer(t *testing.T, infos []string, checks *[]time.Time) *Client {
+	checkAfterUnit = time.Millisecond
+	t.Cleanup(func() { checkAfterUnit = time.Second })
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("command") {
+		case "INIT":
+			fmt.Fprintf(w, `{"media_id": %d}`, testMediaID)
+			return
+		case "APPEND":
+			return
+		case "STATUS":
+			if r.Method != "GET" || r.FormValue("media_id") != testMediaID.String() {
+				t.Errorf("status checked with %s %s", r.Method, r.URL.RawQuery)
+			}
+			*checks = append(*checks, time.Now())
+		}
+		if len(infos) == 0 {
+			t.Error("no processing info left")
+			return
+		}
+		fmt.Fprintf(w, `{"media_id": %d, "processing_info": %s}`, testMediaID, infos[0])
+		infos = infos[1:]
+	})
+}
+
+func TestWaitForProcessing(t *testing.T) {
+	var checks []time.Time
+	c := processingServer(t, []string{
+		`{"state": "in_progress", "check_after_secs": 30, "progress_percent": 40}`,
+		`{"state": "in_progress", "check_after_secs": 20, "progress_percent": 80}`,
+		`{"state": "succeeded", "progress_percent": 100}`,
+	}, &checks)
+	var progress []int
+	m, err := c.Media.WaitForProcessing(context.Background(), testMediaID, func(percent int) {
+		progress = append(progress, percent)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MediaId != testMediaID || m.ProcessingInfo.State != ProcessingSucceeded {
+		t.Errorf("media = %+v", m)
+	}
+	if want := []int{40, 80, 100}; !reflect.DeepEqual(progress, want) {
+		t.Errorf("progress = %v, want %v", progress, want)
+	}
+	if len(checks) != 3 {
+		t.Fatalf("status checked %d times, want 3", len(checks))
+	}
+	for i, after := range []time.Duration{30, 20} {
+		if d := checks[i+1].Sub(checks[i]); d < after*time.Millisecond {
+			t.Errorf("check %d came %v after the previous one, want check_after_secs %d", i+1, d, after)
+		}
+	}
+}
+
+func TestWaitForProcessingFailed(t *testing.T) {
+	var checks []time.Time
+	c := processingServer(t, []string{
+		`{"state": "failed", "progress_percent": 50, "error": {"code": 1,
+			"name": "InvalidMedia", "message": "Unsupported video format"}}`,
+	}, &checks)
+	_, err := c.Media.WaitForProcessing(context.Background(), testMediaID, nil)
+	var perr *MediaProcessingError
+	if !errors.As(err, &perr) {
+		t.Fatalf("err = %v, want a *MediaProcessingError", err)
+	}
+	want := ProcessingError{Code: 1, Name: "InvalidMedia", Message: "Unsupported video format"}
+	if perr.MediaId != testMediaID || perr.ProcessingError != want {
+		t.Errorf("err = %+v", perr)
+	}
+	if msg := "processing of media 710511363345354753 failed: InvalidMedia: Unsupported video format (1)"; perr.Error() != msg {
+		t.Errorf("Error() = %q, want %q", perr.Error(), msg)
+	}
+}
+
+func TestWaitForProcessingCanceled(t *testing.T) {
+	var checks []time.Time
+	c := processingServer(t, []string{
+		`{"state": "pending", "check_after_secs": 3600000}`,
+	}, &checks)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.Media.WaitForProcessing(ctx, testMediaID, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if len(checks) != 1 {
+		t.Errorf("status checked %d times, want 1", len(checks))
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err = c.Media.WaitForProcessing(ctx, testMediaID, nil); err != context.Canceled {
+		t.Errorf("err = %v, want %v", err, context.Canceled)
+	}
+	if len(checks) != 1 {
+		t.Errorf("status checked after the context was canceled")
+	}
+}
+
+func TestUploadAndWait(t *testing.T) {
+	var checks []time.Time
+	c := processingServer(t, []string{
+		`{"state": "pending", "check_after_secs": 5}`,
+		`{"state": "in_progress", "check_after_secs": 5, "progress_percent": 60}`,
+		`{"state": "succeeded", "progress_percent": 100}`,
+	}, &checks)
+	var progress []int
+	opts := &ChunkedUploadOptions{ProcessingProgress: func(percent int) {
+		progress = append(progress, percent)
+	}}
+	opts.MediaCategory = MediaCategoryTweetVideo
+	m, err := c.Media.UploadAndWait(context.Background(), strings.NewReader("mp4"), 3, "video/mp4", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MediaId != testMediaID || m.ProcessingInfo.State != ProcessingSucceeded {
+		t.Errorf("media = %+v", m)
+	}
+	if want := []int{60, 100}; !reflect.DeepEqual(progress, want) {
+		t.Errorf("progress = %v, want %v", progress, want)
+	}
+}
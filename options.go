@@ -0,0 +1,210 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Optionals: used to provide optional arguments to
+// API calls
+//
+// Usage:
+//
+//	opts := NewOptionals()
+//	opts.Set("count", 10)
+//	opts.Set("lat", -37.102013)
+//	opts.Set("user_id", []int64{12, 783214})
+//
+// Values are encoded the way the API expects them: lists are comma
+// separated, times are given in RFC 3339 format and booleans as true or
+// false.
+//
+// Methods taking optionals never modify them, so the same Optionals can be
+// reused across calls, e.g. when paging. Freeze them to catch code that
+// modifies them through Add, Set or Del.
+type Optionals struct {
+	Values url.Values
+	frozen bool
+}
+
+// NewOptionals returns a new instance of Optionals
+func NewOptionals() *Optionals {
+	return &Optionals{Values: make(url.Values)}
+}
+
+// Add: adds a new optional parameter to be used in
+// an API request, after any value it already has
+func (o *Optionals) Add(name string, value interface{}) {
+	o.mutate()
+	o.Values.Add(name, encodeValue(value))
+}
+
+// Sets an optional parameter, replacing any value it already has
+func (o *Optionals) Set(name string, value interface{}) {
+	o.mutate()
+	o.Values.Set(name, encodeValue(value))
+}
+
+// Removes an optional parameter
+func (o *Optionals) Del(name string) {
+	o.mutate()
+	o.Values.Del(name)
+}
+
+// Returns the first value of an optional parameter, or "" if it is not set
+func (o *Optionals) Get(name string) string {
+	if o == nil {
+		return ""
+	}
+	return o.Values.Get(name)
+}
+
+// Returns a copy of the optionals that can be modified even if they are
+// frozen. Cloning nil optionals returns empty ones.
+func (o *Optionals) Clone() *Optionals {
+	c := NewOptionals()
+	if o == nil {
+		return c
+	}
+	for k, v := range o.Values {
+		c.Values[k] = append([]string(nil), v...)
+	}
+	return c
+}
+
+// Marks the optionals as shared, for instance as defaults: Add, Set and Del
+// panic once they are frozen. Values itself is not protected and must not
+// be modified directly; Clone the optionals to get a modifiable copy.
+// Returns the optionals.
+func (o *Optionals) Freeze() *Optionals {
+	o.frozen = true
+	return o
+}
+
+// Reports whether the optionals are frozen
+func (o *Optionals) Frozen() bool {
+	return o != nil && o.frozen
+}
+
+func (o *Optionals) mutate() {
+	if o.frozen {
+		panic("tweetlib: modification of frozen Optionals")
+	}
+}
+
+// Encodes a value of an optional parameter
+func encodeValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case Time:
+		return v.Format(time.RFC3339)
+	case fmt.Stringer:
+		// ID, TimeOfDay, etc
+		return v.String()
+	}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = encodeValue(rv.Index(i).Interface())
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// Returns a pointer to b, for the option fields where leaving the value
+// out and setting it to false differ, e.g.
+//
+//	opts := &TimelineOptions{IncludeRTs: Bool(false)}
+func Bool(b bool) *bool {
+	return &b
+}
+
+// Helpers used by the typed options to only encode the fields that are set
+
+func (o *Optionals) setString(name, value string) {
+	if value != "" {
+		o.Set(name, value)
+	}
+}
+
+func (o *Optionals) setInt(name string, value int) {
+	if value != 0 {
+		o.Set(name, value)
+	}
+}
+
+func (o *Optionals) setID(name string, value ID) {
+	if value != 0 {
+		o.Set(name, value)
+	}
+}
+
+func (o *Optionals) setIDs(name string, values []ID) {
+	if len(values) > 0 {
+		o.Set(name, values)
+	}
+}
+
+// Sets the cursor of the page of a cursored collection to return. Unless
+// it is 0, which the API takes as the first page.
+func (o *Optionals) setCursor(cursor int64) {
+	if cursor != 0 {
+		o.Set("cursor", cursor)
+	}
+}
+
+// Only sets the parameter if value is true, as the API takes a missing
+// parameter as false
+func (o *Optionals) setBool(name string, value bool) {
+	if value {
+		o.Set(name, true)
+	}
+}
+
+func (o *Optionals) setBoolPtr(name string, value *bool) {
+	if value != nil {
+		o.Set(name, *value)
+	}
+}
+
+// Sets a date parameter, such as the "until" of searches, which the API
+// takes as YYYY-MM-DD
+func (o *Optionals) setDate(name string, value time.Time) {
+	if !value.IsZero() {
+		o.Set(name, value.Format("2006-01-02"))
+	}
+}
+
+// Sets the user a call applies to, by screen name if one is given or by
+// id otherwise. Neither is set if both are empty, which the API takes as
+// the authenticating user.
+func (o *Optionals) setUser(screenName string, userID ID) {
+	switch {
+	case screenName != "":
+		o.Set("screen_name", screenName)
+	case userID != 0:
+		o.Set("user_id", userID)
+	}
+}
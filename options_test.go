@@ -0,0 +1,180 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEncodeValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{"text", "text"},
+		{[]byte("bytes"), "bytes"},
+		{true, "true"},
+		{42, "42"},
+		{int64(-7), "-7"},
+		{float32(0.5), "0.5"},
+		{-122.400612831116, "-122.400612831116"},
+		{ID(20), "20"},
+		{TimeOfDay{7, true}, "07"},
+		{[]string{"a", "b"}, "a,b"},
+		{[]int64{12, 783214}, "12,783214"},
+		{[]ID{1, 2, 3}, "1,2,3"},
+		{[2]float64{1.5, 2}, "1.5,2"},
+		// times are encoded in full; only date parameters drop the time
+		{time.Date(2017, 3, 2, 16, 42, 5, 0, time.UTC), "2017-03-02T16:42:05Z"},
+		{time.Date(2017, 3, 2, 18, 42, 5, 0, time.FixedZone("", 2*3600)), "2017-03-02T18:42:05+02:00"},
+		{Time{Time: time.Date(2017, 3, 2, 16, 42, 5, 0, time.UTC)}, "2017-03-02T16:42:05Z"},
+	}
+	for _, tt := range tests {
+		if got := encodeValue(tt.value); got != tt.want {
+			t.Errorf("encodeValue(%#v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSetDate(t *testing.T) {
+	opts := NewOptionals()
+	opts.setDate("until", time.Date(2017, 3, 2, 23, 59, 0, 0, time.UTC))
+	if got := opts.Get("until"); got != "2017-03-02" {
+		t.Errorf("until = %q, want 2017-03-02", got)
+	}
+	opts.setDate("since", time.Time{})
+	if _, set := opts.Values["since"]; set {
+		t.Error("zero date was set")
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	opts := NewOptionals()
+	opts.Set("count", 10)
+	opts.Add("ids", 1)
+	if opts.Frozen() {
+		t.Error("new optionals are frozen")
+	}
+	if opts.Freeze() != opts || !opts.Frozen() {
+		t.Fatal("Freeze did not freeze the optionals")
+	}
+
+	for name, mutate := range map[string]func(){
+		"Set": func() { opts.Set("count", 20) },
+		"Add": func() { opts.Add("ids", 2) },
+		"Del": func() { opts.Del("count") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic on frozen optionals", name)
+				}
+			}()
+			mutate()
+		}()
+	}
+	if got := opts.Values.Encode(); got != "count=10&ids=1" {
+		t.Errorf("frozen optionals were modified: %s", got)
+	}
+
+	// clones can be modified without affecting the original
+	c := opts.Clone()
+	if c.Frozen() {
+		t.Error("clone of frozen optionals is frozen")
+	}
+	c.Set("count", 20)
+	c.Add("ids", 2)
+	if got := c.Values.Encode(); got != "count=20&ids=1&ids=2" {
+		t.Errorf("clone = %s", got)
+	}
+	if got := opts.Values.Encode(); got != "count=10&ids=1" {
+		t.Errorf("modifying the clone changed the original: %s", got)
+	}
+
+	var none *Optionals
+	if none.Frozen() || none.Get("count") != "" || none.Clone() == nil {
+		t.Error("nil optionals are not usable as empty ones")
+	}
+}
+
+// Options whose Optionals method encodes them as parameters
+type optioner interface {
+	Optionals() *Optionals
+}
+
+func TestTypedOptions(t *testing.T) {
+	until := time.Date(2017, 3, 2, 16, 42, 5, 0, time.UTC)
+	tests := []struct {
+		opts optioner
+		want string
+	}{
+		{&TimelineOptions{}, ""},
+		{(*TimelineOptions)(nil), ""},
+		{
+			&TimelineOptions{Count: 200, SinceID: 10, MaxID: 20, TrimUser: true, ExcludeReplies: true,
+				IncludeRTs: Bool(false), IncludeEntities: Bool(true), ContributorDetails: true},
+			"contributor_details=true&count=200&exclude_replies=true&include_entities=true&include_rts=false&max_id=20&since_id=10&trim_user=true",
+		},
+		{
+			&TweetOptions{TrimUser: true, IncludeMyRetweet: true, IncludeEntities: Bool(false), IncludeExtAltText: true},
+			"include_entities=false&include_ext_alt_text=true&include_my_retweet=true&trim_user=true",
+		},
+		{&RetweetsOptions{Count: 100, TrimUser: true}, "count=100&trim_user=true"},
+		{
+			&UpdateOptions{Location: NewPoint(37.78, -122.4), PlaceId: "df51dec6f4ee2b2c", DisplayCoordinates: true,
+				InReplyToStatusID: 123, AutoPopulateReplyMetadata: true, ExcludeReplyUserIDs: []ID{1, 2},
+				AttachmentUrl: "https://twitter.com/a/status/1", PossiblySensitive: true, TrimUser: true, MediaIds: []ID{3, 4}},
+			"attachment_url=https%3A%2F%2Ftwitter.com%2Fa%2Fstatus%2F1&auto_populate_reply_metadata=true&display_coordinates=true" +
+				"&exclude_reply_user_ids=1%2C2&in_reply_to_status_id=123&lat=37.78&long=-122.4&media_ids=3%2C4" +
+				"&place_id=df51dec6f4ee2b2c&possibly_sensitive=true&trim_user=true",
+		},
+		{
+			&SearchOptions{Geocode: "37.78,-122.40,1km", Lang: "en", Locale: "ja", ResultType: "recent", Count: 100,
+				Until: until, SinceID: 1, MaxID: 2, IncludeEntities: Bool(false)},
+			"count=100&geocode=37.78%2C-122.40%2C1km&include_entities=false&lang=en&locale=ja&max_id=2&result_type=recent&since_id=1&until=2017-03-02",
+		},
+		{&UserOptions{IncludeEntities: Bool(false), SkipStatus: true, IncludeEmail: true}, "include_email=true&include_entities=false&skip_status=true"},
+		{&UserSearchOptions{Page: 2, Count: 20, IncludeEntities: Bool(true)}, "count=20&include_entities=true&page=2"},
+		{
+			&DMOptions{Count: 50, SinceID: 1, MaxID: 2, Page: 3, IncludeEntities: Bool(false), SkipStatus: true, FullText: true},
+			"count=50&full_text=true&include_entities=false&max_id=2&page=3&since_id=1&skip_status=true",
+		},
+		{
+			&SettingsOptions{TrendLocationWoeid: 1, SleepTimeEnabled: Bool(true), StartSleepTime: TimeOfDay{23, true},
+				EndSleepTime: TimeOfDay{7, true}, TimeZone: "Europe/Copenhagen", Lang: "da"},
+			"end_sleep_time=07&lang=da&sleep_time_enabled=true&start_sleep_time=23&time_zone=Europe%2FCopenhagen&trend_location_woeid=1",
+		},
+		{
+			&ProfileOptions{Name: "Go", Url: "https://golang.org", Location: "Earth", Description: "Gopher",
+				ProfileLinkColor: "0000FF", IncludeEntities: Bool(false), SkipStatus: true},
+			"description=Gopher&include_entities=false&location=Earth&name=Go&profile_link_color=0000FF&skip_status=true&url=https%3A%2F%2Fgolang.org",
+		},
+		{
+			&ProfileColorsOptions{ProfileBackgroundColor: "000000", ProfileLinkColor: "111111", ProfileSidebarBorderColor: "222222",
+				ProfileSidebarFillColor: "333333", ProfileTextColor: "444444"},
+			"profile_background_color=000000&profile_link_color=111111&profile_sidebar_border_color=222222&profile_sidebar_fill_color=333333&profile_text_color=444444",
+		},
+		{&CursorOptions{Count: 5000, StringifyIDs: true}, "count=5000&stringify_ids=true"},
+		{&UserCursorOptions{Count: 200, SkipStatus: true, IncludeUserEntities: Bool(false)}, "count=200&include_user_entities=false&skip_status=true"},
+		{&ListsOptions{Reverse: true}, "reverse=true"},
+		{&ListOptions{Name: "gophers", Mode: "private", Description: "Go people"}, "description=Go+people&mode=private&name=gophers"},
+		{&FavoritesOptions{Count: 200, SinceID: 1, MaxID: 2, IncludeEntities: Bool(false)}, "count=200&include_entities=false&max_id=2&since_id=1"},
+		{&FriendshipOptions{Device: Bool(true), Retweets: Bool(false)}, "device=true&retweets=false"},
+		{
+			&GeoSearchOptions{Query: "Toronto", Location: NewPoint(43.65, -79.38), IP: "74.125.19.104", Accuracy: "5ft",
+				Granularity: "city", MaxResults: 3, ContainedWithin: "3797791ff9c0e4c6"},
+			"accuracy=5ft&contained_within=3797791ff9c0e4c6&granularity=city&ip=74.125.19.104&lat=43.65&long=-79.38&max_results=3&query=Toronto",
+		},
+		{&UploadOptions{MediaCategory: MediaCategoryTweetVideo, AdditionalOwners: []ID{5, 6}, Shared: true}, "additional_owners=5%2C6&media_category=tweet_video&shared=true"},
+	}
+	for _, tt := range tests {
+		if got := tt.opts.Optionals().Values.Encode(); got != tt.want {
+			t.Errorf("%T:\ngot  %s\nwant %s", tt.opts, got, tt.want)
+		}
+	}
+}
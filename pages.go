@@ -0,0 +1,110 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import "errors"
+
+// ErrStopPaging can be returned by the function given to the paging methods
+// (e.g. FavoritesService.ListPages) to stop paging, wrapped or not. The
+// paging method then returns nil.
+var ErrStopPaging = errors.New("stop paging")
+
+// Pages through a timeline of tweets by max_id: every page is requested
+// with a max_id just below the oldest tweet of the previous one, until a
+// page comes back empty or fn returns an error. A max_id set in opts is
+// used for the first page.
+func maxIDPages(opts *Optionals, fetch func(opts *Optionals) (TweetList, error), fn func(TweetList) error) error {
+	opts = opts.Clone()
+	for {
+		tweets, err := fetch(opts)
+		if err != nil {
+			return err
+		}
+		if len(tweets) == 0 {
+			return nil
+		}
+		if err = fn(tweets); errors.Is(err, ErrStopPaging) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		oldest := tweets[len(tweets)-1].Id
+		for _, t := range tweets {
+			if t.Id < oldest {
+				oldest = t.Id
+			}
+		}
+		if oldest <= 1 {
+			return nil
+		}
+		opts.Set("max_id", oldest-1)
+	}
+}
+
+// Position of a page within a cursored collection. Next is 0 on the last
+// page and Previous is 0 on the first one.
+// See https://dev.twitter.com/overview/api/cursoring
+type CursorPosition struct {
+	Next        int64  `json:"next_cursor"`
+	NextStr     string `json:"next_cursor_str"`
+	Previous    int64  `json:"previous_cursor"`
+	PreviousStr string `json:"previous_cursor_str"`
+}
+
+// A page of a cursored collection of users
+type UserCursor struct {
+	CursorPosition
+	Users []User `json:"users"`
+}
+
+// Pages through a cursored collection: page is called with the optionals
+// to request every page with, starting from the cursor set in opts or from
+// the first page, until the last page has been requested or page returns
+// an error.
+func cursorPages(opts *Optionals, page func(opts *Optionals) (*CursorPosition, error)) error {
+	opts = opts.Clone()
+	if opts.Get("cursor") == "" {
+		opts.Set("cursor", -1)
+	}
+	for {
+		pos, err := page(opts)
+		if errors.Is(err, ErrStopPaging) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if pos.Next == 0 {
+			return nil
+		}
+		opts.Set("cursor", pos.Next)
+	}
+}
+
+// Calls call with the given users, at most size of them at a time. Users
+// given by screen name and by id are sent in separate calls, as the
+// "screen_name" and "user_id" parameters respectively.
+func userBatches(screenNames []string, userIDs []ID, size int, call func(param string, users interface{}) error) error {
+	for i := 0; i < len(screenNames); i += size {
+		end := i + size
+		if end > len(screenNames) {
+			end = len(screenNames)
+		}
+		if err := call("screen_name", screenNames[i:end]); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < len(userIDs); i += size {
+		end := i + size
+		if end > len(userIDs) {
+			end = len(userIDs)
+		}
+		if err := call("user_id", userIDs[i:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// Serves favorites/list from a timeline of tweets with the given ids, newest
+// first, three at a time, and records the max_id of every request
+func timelineServer(t *testing.T, ids []ID, maxIDs *[]string) *Client {
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/favorites/list.json" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		maxID := r.FormValue("max_id")
+		*maxIDs = append(*maxIDs, maxID)
+		page := []Tweet{}
+		for _, id := range ids {
+			if max, _ := strconv.ParseInt(maxID, 10, 64); maxID != "" && int64(id) > max {
+				continue
+			}
+			if len(page) < 3 {
+				page = append(page, Tweet{Id: id})
+			}
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+}
+
+func TestMaxIDPages(t *testing.T) {
+	tests := []struct {
+		name   string
+		ids    []ID
+		opts   *Optionals
+		pages  int
+		maxIDs []string
+	}{
+		{
+			// every page asks for the tweets below the oldest one so far
+			"until the oldest tweet",
+			[]ID{10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+			nil,
+			4,
+			[]string{"", "7", "4", "1"},
+		},
+		{
+			"until an empty page",
+			[]ID{100, 90, 80, 70, 60, 50},
+			nil,
+			2,
+			[]string{"", "79", "49"},
+		},
+		{
+			"from the given max_id",
+			[]ID{10, 9, 8, 7, 6, 5},
+			(&FavoritesOptions{MaxID: 8}).Optionals(),
+			2,
+			[]string{"8", "5", "4"},
+		},
+		{
+			"empty timeline",
+			nil,
+			nil,
+			0,
+			[]string{""},
+		},
+	}
+	for _, tt := range tests {
+		var maxIDs []string
+		c := timelineServer(t, tt.ids, &maxIDs)
+		pages := 0
+		err := c.Favorites.ListPages("golang", tt.opts, func(tweets TweetList) error {
+			pages++
+			return nil
+		})
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		if pages != tt.pages || fmt.Sprint(maxIDs) != fmt.Sprint(tt.maxIDs) {
+			t.Errorf("%s: got %d pages with max_id %q, want %d with %q", tt.name, pages, maxIDs, tt.pages, tt.maxIDs)
+		}
+	}
+}
+
+func TestMaxIDPagesStop(t *testing.T) {
+	fail := errors.New("fail")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"ErrStopPaging", ErrStopPaging, nil},
+		{"wrapped ErrStopPaging", fmt.Errorf("done: %w", ErrStopPaging), nil},
+		{"other error", fail, fail},
+	}
+	for _, tt := range tests {
+		var maxIDs []string
+		c := timelineServer(t, []ID{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, &maxIDs)
+		pages := 0
+		err := c.Favorites.ListPages("", nil, func(tweets TweetList) error {
+			pages++
+			if pages == 2 {
+				return tt.err
+			}
+			return nil
+		})
+		if err != tt.want {
+			t.Errorf("%s: ListPages = %v, want %v", tt.name, err, tt.want)
+		}
+		if pages != 2 || len(maxIDs) != 2 {
+			t.Errorf("%s: paging went on after the error: %d pages, %d requests", tt.name, pages, len(maxIDs))
+		}
+	}
+}
+
+func TestMaxIDPagesError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"errors": [{"code": 88, "message": "Rate limit exceeded"}]}`))
+	})
+	called := false
+	err := c.Favorites.ListPages("", nil, func(TweetList) error {
+		called = true
+		return nil
+	})
+	if err == nil || called {
+		t.Errorf("ListPages = %v and called fn = %v, want an error before any page", err, called)
+	}
+}
+
+// Serves the cursored collection at path in pages, the items of each page
+// being the JSON of the array member named key. Page i is served for cursor
+// i, and for cursor -1 if it is the first one. The queries of the requests
+// are recorded in queries.
+func cursorServer(t *testing.T, path, key string, pages []string, queries *[]url.Values) *Client {
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		r.ParseForm()
+		*queries = append(*queries, r.Form)
+		i, err := strconv.Atoi(r.Form.Get("cursor"))
+		if i == -1 {
+			i = 0
+		}
+		if err != nil || i < 0 || i >= len(pages) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		next := i + 1
+		if next == len(pages) {
+			next = 0
+		}
+		fmt.Fprintf(w, `{"%s": %s, "next_cursor": %d, "next_cursor_str": "%d", "previous_cursor": %d}`,
+			key, pages[i], next, next, -i)
+	})
+}
+
+// Returns the "cursor" parameter of each query
+func cursors(queries []url.Values) []string {
+	var cs []string
+	for _, q := range queries {
+		cs = append(cs, q.Get("cursor"))
+	}
+	return cs
+}
+
+func TestCursorPages(t *testing.T) {
+	pages := []string{`[{"id": 1}, {"id": 2}]`, `[{"id": 3}]`, `[{"id": 4}]`}
+	fail := errors.New("fail")
+	tests := []struct {
+		name    string
+		opts    *Optionals
+		stopAt  int
+		stop    error
+		err     error
+		ids     []ID
+		cursors []string
+	}{
+		{"all pages", nil, 0, nil, nil, []ID{1, 2, 3, 4}, []string{"-1", "1", "2"}},
+		{"from the given cursor", &Optionals{Values: url.Values{"cursor": {"1"}}}, 0, nil, nil, []ID{3, 4}, []string{"1", "2"}},
+		{"ErrStopPaging", nil, 2, ErrStopPaging, nil, []ID{1, 2, 3}, []string{"-1", "1"}},
+		{"wrapped ErrStopPaging", nil, 1, fmt.Errorf("enough: %w", ErrStopPaging), nil, []ID{1, 2}, []string{"-1"}},
+		{"other error", nil, 2, fail, fail, []ID{1, 2, 3}, []string{"-1", "1"}},
+	}
+	for _, tt := range tests {
+		var queries []url.Values
+		c := cursorServer(t, "/lists/members.json", "users", pages, &queries)
+		var ids []ID
+		page := 0
+		err := c.Lists.MembersPages(ListBySlug("team", "golang"), tt.opts, func(users []User) error {
+			for _, u := range users {
+				ids = append(ids, u.Id)
+			}
+			if page++; page == tt.stopAt {
+				return tt.stop
+			}
+			return nil
+		})
+		if err != tt.err {
+			t.Errorf("%s: MembersPages = %v, want %v", tt.name, err, tt.err)
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(tt.ids) || fmt.Sprint(cursors(queries)) != fmt.Sprint(tt.cursors) {
+			t.Errorf("%s: got users %v with cursors %q, want %v with %q", tt.name, ids, cursors(queries), tt.ids, tt.cursors)
+		}
+		for _, q := range queries {
+			if q.Get("slug") != "team" || q.Get("owner_screen_name") != "golang" {
+				t.Errorf("%s: list not given in %v", tt.name, q)
+			}
+		}
+	}
+}
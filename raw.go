@@ -0,0 +1,298 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// RawJSON is embedded in the model types (Tweet, User, DirectMessage, List
+// and the search results and metadata) to keep track of the JSON they are
+// decoded from.
+//
+// Encoding a model value decoded from JSON back to JSON gives the same
+// members: those it does not model are kept in Extra, and those that were
+// missing are left out rather than encoded with their zero value.
+type RawJSON struct {
+	// The JSON the value was decoded from. Only set if the client's
+	// KeepRawJSON is.
+	Raw json.RawMessage `json:"-"`
+	// Members of the JSON object that are not modelled by the fields of the
+	// value. They are preserved when encoding back to JSON.
+	Extra map[string]json.RawMessage `json:"-"`
+
+	// members of the JSON the value was decoded from, recursively
+	shape *jsonShape
+}
+
+func (r *RawJSON) setRaw(raw json.RawMessage) { r.Raw = raw }
+
+// Decodes data into v, the model value r is embedded in converted to a type
+// without an UnmarshalJSON method, and records its extra members and shape
+func (r *RawJSON) decodeJSON(data []byte, v interface{}) (err error) {
+	r.Extra, err = unmarshalWithExtra(data, v)
+	r.shape = readShape(json.NewDecoder(bytes.NewReader(data)))
+	return
+}
+
+// Encodes v, the model value r is embedded in converted to a type without
+// a MarshalJSON method, with the members of the JSON it was decoded from
+func (r *RawJSON) encodeJSON(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return marshalWithExtra(pruneJSON(data, r.shape), r.Extra), nil
+}
+
+// Implemented by the model types through RawJSON
+type rawHolder interface {
+	setRaw(raw json.RawMessage)
+}
+
+var rawHolderType = reflect.TypeOf((*rawHolder)(nil)).Elem()
+
+// Decodes data into v, a pointer to a struct without an UnmarshalJSON
+// method, and returns the members of data that no field of v decodes.
+// Like json.Unmarshal, a type mismatch is returned only once every field
+// has been decoded.
+func unmarshalWithExtra(data []byte, v interface{}) (extra map[string]json.RawMessage, err error) {
+	err = json.Unmarshal(data, v)
+	var typeErr *json.UnmarshalTypeError
+	if err != nil && !errors.As(err, &typeErr) {
+		return nil, err
+	}
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(data, &obj) != nil {
+		return nil, err
+	}
+	fields := jsonFields(reflect.TypeOf(v).Elem())
+	for name := range obj {
+		if _, ok := fields.lookup(name); ok {
+			delete(obj, name)
+		}
+	}
+	if len(obj) > 0 {
+		extra = obj
+	}
+	return extra, err
+}
+
+// Appends the extra members given to data, an encoded JSON object
+func marshalWithExtra(data []byte, extra map[string]json.RawMessage) []byte {
+	if len(extra) == 0 {
+		return data
+	}
+	buf := bytes.NewBuffer(data[:len(data)-1])
+	empty := len(data) == 2
+	for _, name := range sortedKeys(extra) {
+		if !empty {
+			buf.WriteByte(',')
+		}
+		empty = false
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(extra[name])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
+
+// The structure of a decoded JSON value: the members of an object or the
+// elements of an array, and whether it was null. Values of other kinds are
+// represented by nil.
+type jsonShape struct {
+	null     bool
+	members  map[string]*jsonShape
+	elements []*jsonShape
+}
+
+// Reads the next value from dec and returns its shape
+func readShape(dec *json.Decoder) *jsonShape {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil
+	}
+	switch tok {
+	case nil:
+		return &jsonShape{null: true}
+	case json.Delim('{'):
+		s := &jsonShape{members: make(map[string]*jsonShape)}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return s
+			}
+			s.members[key.(string)] = readShape(dec)
+		}
+		dec.Token()
+		return s
+	case json.Delim('['):
+		s := &jsonShape{elements: []*jsonShape{}}
+		for dec.More() {
+			s.elements = append(s.elements, readShape(dec))
+		}
+		dec.Token()
+		return s
+	}
+	return nil
+}
+
+// Removes from data, an encoded JSON value, the empty members that were
+// not in the value of the given shape, and encodes back as null the empty
+// values that were null
+func pruneJSON(data []byte, s *jsonShape) []byte {
+	switch {
+	case s == nil:
+		return data
+	case s.null:
+		if isEmptyJSON(data) {
+			return []byte("null")
+		}
+		return data
+	case s.members != nil && data[0] == '{':
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.Token()
+		buf := bytes.NewBufferString("{")
+		for dec.More() {
+			tok, _ := dec.Token()
+			var value json.RawMessage
+			dec.Decode(&value)
+			member, ok := s.members[tok.(string)]
+			if !ok && isEmptyJSON(value) {
+				continue
+			}
+			if buf.Len() > 1 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(tok)
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(pruneJSON(value, member))
+		}
+		buf.WriteByte('}')
+		return buf.Bytes()
+	case s.elements != nil && data[0] == '[':
+		var elems []json.RawMessage
+		if json.Unmarshal(data, &elems) != nil {
+			return data
+		}
+		buf := bytes.NewBufferString("[")
+		for i, elem := range elems {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if i < len(s.elements) {
+				elem = pruneJSON(elem, s.elements[i])
+			}
+			buf.Write(elem)
+		}
+		buf.WriteByte(']')
+		return buf.Bytes()
+	}
+	return data
+}
+
+// Reports whether data, an encoded JSON value, is the encoding of a zero
+// value: null, false, 0, "", or an array or object of such values
+func isEmptyJSON(data []byte) bool {
+	var v interface{}
+	if json.Unmarshal(data, &v) != nil {
+		return false
+	}
+	return isEmptyValue(v)
+}
+
+func isEmptyValue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case []interface{}:
+		for _, e := range v {
+			if !isEmptyValue(e) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		for _, e := range v {
+			if !isEmptyValue(e) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Stores in the Raw field of every model value within v (v itself included)
+// the part of data it was decoded from.
+func retainRaw(data []byte, v reflect.Value) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.CanAddr() && v.Addr().Type().Implements(rawHolderType) {
+			v.Addr().Interface().(rawHolder).setRaw(append(json.RawMessage(nil), data...))
+		}
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			return
+		}
+		fields := jsonFields(v.Type())
+		for name, member := range obj {
+			f, ok := fields.lookup(name)
+			if !ok || !mayHoldRaw(f.typ) {
+				continue
+			}
+			if fv, err := v.FieldByIndexErr(f.index); err == nil {
+				retainRaw(member, fv)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if !mayHoldRaw(v.Type().Elem()) {
+			return
+		}
+		var elems []json.RawMessage
+		if json.Unmarshal(data, &elems) != nil {
+			return
+		}
+		for i := 0; i < len(elems) && i < v.Len(); i++ {
+			retainRaw(elems[i], v.Index(i))
+		}
+	}
+}
+
+// Reports whether values of type t may contain model values
+func mayHoldRaw(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != timeType
+	case reflect.Slice, reflect.Array:
+		return mayHoldRaw(t.Elem())
+	case reflect.Interface:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+// Decodes data as generic JSON, to compare JSON documents regardless of
+// formatting and member order
+func genericJSON(t *testing.T, data []byte) interface{} {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, data)
+	}
+	return v
+}
+
+// Reports the paths at which two generic JSON values differ
+func jsonDiff(path string, a, b interface{}) (diffs []string) {
+	am, aok := a.(map[string]interface{})
+	bm, bok := b.(map[string]interface{})
+	if aok && bok {
+		for k, av := range am {
+			bv, ok := bm[k]
+			if !ok {
+				diffs = append(diffs, path+"."+k+": missing")
+				continue
+			}
+			diffs = append(diffs, jsonDiff(path+"."+k, av, bv)...)
+		}
+		for k, bv := range bm {
+			if _, ok := am[k]; !ok {
+				diffs = append(diffs, path+"."+k+": unexpected "+string(mustMarshal(bv)))
+			}
+		}
+		return diffs
+	}
+	if !reflect.DeepEqual(a, b) {
+		diffs = append(diffs, path+": "+string(mustMarshal(a))+" != "+string(mustMarshal(b)))
+	}
+	return diffs
+}
+
+func mustMarshal(v interface{}) []byte {
+	data, _ := json.Marshal(v)
+	return data
+}
+
+func TestTweetRoundTrip(t *testing.T) {
+	fixture, err := ioutil.ReadFile("testdata/tweet.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tweet Tweet
+	if err := json.Unmarshal(fixture, &tweet); err != nil {
+		t.Fatal(err)
+	}
+	if tweet.Extra["scopes"] == nil || tweet.User.Extra["translator_type"] == nil {
+		t.Errorf("unmodelled members not kept: %v, %v", tweet.Extra, tweet.User.Extra)
+	}
+	out, err := json.Marshal(tweet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range jsonDiff("", genericJSON(t, fixture), genericJSON(t, out)) {
+		t.Error(d)
+	}
+}
+
+func TestRoundTripKeepsChanges(t *testing.T) {
+	var tweet Tweet
+	if err := json.Unmarshal([]byte(`{"id": 1, "in_reply_to_status_id": null}`), &tweet); err != nil {
+		t.Fatal(err)
+	}
+	tweet.Text = "added"
+	tweet.InReplyToStatusId = 5
+	out, _ := json.Marshal(tweet)
+	want := `{"id": 1, "in_reply_to_status_id": 5, "text": "added"}`
+	for _, d := range jsonDiff("", genericJSON(t, []byte(want)), genericJSON(t, out)) {
+		t.Error(d)
+	}
+}
+
+func TestMarshalWithoutDecoding(t *testing.T) {
+	// values that were not decoded are encoded in full
+	out, err := json.Marshal(User{Id: 1, ScreenName: "gopher"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := genericJSON(t, out).(map[string]interface{})
+	for _, member := range []string{"id", "screen_name", "followers_count", "protected"} {
+		if _, ok := u[member]; !ok {
+			t.Errorf("member %q missing from %s", member, out)
+		}
+	}
+}
+
+func TestKeepRawJSON(t *testing.T) {
+	c := &Client{KeepRawJSON: true}
+	data := []byte(`[{"id": 1, "user": {"id": 2, "screen_name": "gopher"}}]`)
+	var tweets TweetList
+	if err := c.decode("statuses/user_timeline", data, &tweets); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(tweets[0].Raw); got != `{"id": 1, "user": {"id": 2, "screen_name": "gopher"}}` {
+		t.Errorf("tweet Raw = %s", got)
+	}
+	if got := string(tweets[0].User.Raw); got != `{"id": 2, "screen_name": "gopher"}` {
+		t.Errorf("user Raw = %s", got)
+	}
+}
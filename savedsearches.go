@@ -0,0 +1,77 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import "fmt"
+
+// Groups functions to manage the saved searches of the authenticating user
+type SavedSearchesService struct {
+	*Client
+}
+
+// A search query saved by the authenticating user
+type SavedSearch struct {
+	Id        ID     `json:"id"`
+	IdStr     string `json:"id_str"`
+	Name      string `json:"name"`
+	Query     string `json:"query"`
+	Position  *int   `json:"position"`
+	CreatedAt Time   `json:"created_at"`
+}
+
+// A list of saved searches
+type SavedSearchList []SavedSearch
+
+// Returns the saved searches of the authenticating user
+// See https://dev.twitter.com/docs/api/1.1/get/saved_searches/list
+func (ss *SavedSearchesService) List() (searches *SavedSearchList, err error) {
+	searches = &SavedSearchList{}
+	err = ss.Call("GET", "saved_searches/list", nil, searches)
+	return
+}
+
+// Returns a saved search of the authenticating user
+// See https://dev.twitter.com/docs/api/1.1/get/saved_searches/show/%3Aid
+func (ss *SavedSearchesService) Show(id ID) (search *SavedSearch, err error) {
+	search = &SavedSearch{}
+	err = ss.Call("GET", fmt.Sprintf("saved_searches/show/%s", id), nil, search)
+	return
+}
+
+// Saves a search query for the authenticating user, who can have up to 25
+// saved searches
+// See https://dev.twitter.com/docs/api/1.1/post/saved_searches/create
+func (ss *SavedSearchesService) Create(query string) (search *SavedSearch, err error) {
+	opts := NewOptionals()
+	opts.Set("query", query)
+	search = &SavedSearch{}
+	err = ss.Call("POST", "saved_searches/create", opts, search)
+	return
+}
+
+// Deletes a saved search of the authenticating user. Returns the deleted
+// search.
+// See https://dev.twitter.com/docs/api/1.1/post/saved_searches/destroy/%3Aid
+func (ss *SavedSearchesService) Destroy(id ID) (search *SavedSearch, err error) {
+	search = &SavedSearch{}
+	err = ss.Call("POST", fmt.Sprintf("saved_searches/destroy/%s", id), nil, search)
+	return
+}
+
+// Runs a saved search with Search.Tweets, which takes the same options as
+// any search (see SearchOptions)
+//
+// Usage:
+//
+//	searches, _ := client.SavedSearches.List()
+//	for _, s := range searches {
+//		results, err := client.SavedSearches.Run(&s, nil)
+//		...
+//	}
+func (ss *SavedSearchesService) Run(search *SavedSearch, opts *Optionals) (searchResults *SearchResults, err error) {
+	return ss.Search.Tweets(search.Query, opts)
+}
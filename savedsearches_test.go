@@ -0,0 +1,80 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+const savedSearchJSON = `{"id": 9569704, "id_str": "9569704", "name": "@twitterapi",
+	"query": "@twitterapi", "position": null, "created_at": "Mon Jun 20 00:37:31 +0000 2011"}`
+
+func TestSavedSearches(t *testing.T) {
+	var method, path, query string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		method, path, query = r.Method, r.URL.Path, r.URL.Query().Encode()
+		if r.Method == "POST" {
+			query = r.PostForm.Encode()
+		}
+		switch r.URL.Path {
+		case "/saved_searches/list.json":
+			w.Write([]byte(`[` + savedSearchJSON + `, {"id": 2, "name": "go", "query": "golang", "position": 1}]`))
+		case "/search/tweets.json":
+			w.Write([]byte(`{"statuses": [], "search_metadata": {"query": "%40twitterapi"}}`))
+		default:
+			w.Write([]byte(savedSearchJSON))
+		}
+	})
+
+	searches, err := c.SavedSearches.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*searches) != 2 {
+		t.Fatalf("got %d searches", len(*searches))
+	}
+	s := (*searches)[0]
+	if s.Id != 9569704 || s.Query != "@twitterapi" || s.Position != nil ||
+		!s.CreatedAt.Equal(time.Date(2011, 6, 20, 0, 37, 31, 0, time.UTC)) {
+		t.Errorf("search = %+v", s)
+	}
+	if p := (*searches)[1].Position; p == nil || *p != 1 {
+		t.Errorf("position = %v, want 1", p)
+	}
+
+	tests := []struct {
+		call                func() (*SavedSearch, error)
+		method, path, query string
+	}{
+		{func() (*SavedSearch, error) { return c.SavedSearches.Show(9569704) }, "GET", "/saved_searches/show/9569704.json", ""},
+		{func() (*SavedSearch, error) { return c.SavedSearches.Destroy(9569704) }, "POST", "/saved_searches/destroy/9569704.json", ""},
+		{func() (*SavedSearch, error) { return c.SavedSearches.Create("@twitterapi") }, "POST", "/saved_searches/create.json", "query=%40twitterapi"},
+	}
+	for _, tt := range tests {
+		s, err := tt.call()
+		if err != nil {
+			t.Errorf("%s: %v", tt.path, err)
+			continue
+		}
+		if method != tt.method || path != tt.path || query != tt.query {
+			t.Errorf("requested %s %s with %s, want %s %s with %s", method, path, query, tt.method, tt.path, tt.query)
+		}
+		if s.Id != 9569704 {
+			t.Errorf("%s: search = %+v", tt.path, s)
+		}
+	}
+
+	if _, err = c.SavedSearches.Run(&(*searches)[0], nil); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/search/tweets.json" || query != "q=%40twitterapi" {
+		t.Errorf("Run requested %s with %s", path, query)
+	}
+}
@@ -6,6 +6,8 @@
 
 package tweetlib
 
+import "time"
+
 // Groups search functionality
 type SearchService struct {
 	*Client
@@ -17,13 +19,25 @@ type SearchResults struct {
 	Results TweetList `json:"statuses"`
 	// Search metadata
 	Metadata SearchMetadata `json:"search_metadata"`
+
+	RawJSON
+}
+
+func (sr *SearchResults) UnmarshalJSON(data []byte) error {
+	type searchResults SearchResults
+	return sr.decodeJSON(data, (*searchResults)(sr))
+}
+
+func (sr SearchResults) MarshalJSON() ([]byte, error) {
+	type searchResults SearchResults
+	return sr.encodeJSON((*searchResults)(&sr))
 }
 
 // When searching, Twitter returns this metadata
 // along with results
 type SearchMetadata struct {
-	MaxId       int64   `json:"max_id"`
-	SinceId     int64   `json:"since_id"`
+	MaxId       ID      `json:"max_id"`
+	SinceId     ID      `json:"since_id"`
 	RefreshUrl  string  `json:"refresh_url"`
 	NextResults string  `json:"next_results"`
 	Count       int     `json:"count"`
@@ -31,16 +45,74 @@ type SearchMetadata struct {
 	SinceIdStr  string  `json:"since_id_str"`
 	Query       string  `json:"query"`
 	MaxIdStr    string  `json:"max_id_str"`
+
+	RawJSON
+}
+
+func (sm *SearchMetadata) UnmarshalJSON(data []byte) error {
+	type searchMetadata SearchMetadata
+	return sm.decodeJSON(data, (*searchMetadata)(sm))
+}
+
+func (sm SearchMetadata) MarshalJSON() ([]byte, error) {
+	type searchMetadata SearchMetadata
+	return sm.encodeJSON((*searchMetadata)(&sm))
+}
+
+// Options of the search methods, SearchService.Tweets and
+// TweetsService.Tweets
+//
+// Usage:
+//
+//	opts := &SearchOptions{ResultType: "recent", Count: 100, Until: time.Now()}
+//	results, err := client.Search.Tweets("#golang", opts.Optionals())
+type SearchOptions struct {
+	// Restricts the search to users located within a radius of a point,
+	// given as "latitude,longitude,radius" (e.g. "37.78,-122.40,1km")
+	Geocode string
+	// Restricts the search to tweets in a language, given as an ISO 639-1
+	// code
+	Lang string
+	// Language of the query. Only "ja" is effective.
+	Locale string
+	// Either "mixed", "recent" or "popular"
+	ResultType string
+	// Number of tweets to return per page, up to 100
+	Count int
+	// Only return tweets created before this date
+	Until time.Time
+	// Only return tweets more recent than this one
+	SinceID ID
+	// Only return tweets older than or equal to this one
+	MaxID ID
+	// Whether to include entities
+	IncludeEntities *bool
+}
+
+// Returns the optionals to pass to the search methods
+func (so *SearchOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if so == nil {
+		return opts
+	}
+	opts.setString("geocode", so.Geocode)
+	opts.setString("lang", so.Lang)
+	opts.setString("locale", so.Locale)
+	opts.setString("result_type", so.ResultType)
+	opts.setInt("count", so.Count)
+	opts.setDate("until", so.Until)
+	opts.setID("since_id", so.SinceID)
+	opts.setID("max_id", so.MaxID)
+	opts.setBoolPtr("include_entities", so.IncludeEntities)
+	return opts
 }
 
 // Returns a collection of relevant Tweets matching a specified query.
 // See https://dev.twitter.com/docs/api/1.1/get/search/tweets
 // and also https://dev.twitter.com/docs/using-search
 func (sg *SearchService) Tweets(q string, opts *Optionals) (searchResults *SearchResults, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	opts.Add("q", q)
+	opts = opts.Clone()
+	opts.Set("q", q)
 	searchResults = &SearchResults{}
 	err = sg.Call("GET", "search/tweets", opts, searchResults)
 	return
@@ -0,0 +1,173 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layout of the timestamps returned by most of Twitter's REST API
+// (e.g. "Wed Aug 27 13:08:45 +0000 2008")
+const TimeLayout = time.RubyDate
+
+// Layouts tried, in order, when decoding a timestamp. Newer endpoints
+// (trends, for example) use ISO-8601 instead of TimeLayout.
+var timeLayouts = []string{
+	TimeLayout,
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05.000Z07:00",
+	"2006-01-02",
+}
+
+// Used as layout for timestamps given as seconds since the epoch
+const unixLayout = "unix"
+
+// Time is a timestamp as returned by the Twitter API. It embeds time.Time so
+// it can be used as such, and it remembers the format it was decoded from so
+// that encoding it back produces the same representation.
+type Time struct {
+	time.Time
+	layout string
+}
+
+// ParseTime parses a timestamp in any of the formats used by the Twitter
+// API.
+func ParseTime(s string) (t Time, err error) {
+	var first Time
+	for _, layout := range timeLayouts {
+		parsed, perr := time.Parse(layout, s)
+		if perr != nil {
+			continue
+		}
+		// time.Parse accepts fractional seconds even if the layout does
+		// not have them, so prefer the layout that reproduces s exactly
+		if parsed.Format(layout) == s {
+			return Time{parsed, layout}, nil
+		}
+		if first.IsZero() {
+			first = Time{parsed, layout}
+		}
+	}
+	if !first.IsZero() {
+		return first, nil
+	}
+	return t, fmt.Errorf("tweetlib: cannot parse %q as a timestamp", s)
+}
+
+// UnmarshalJSON decodes a timestamp given either as a string in one of the
+// formats used by Twitter or as a number of seconds since the epoch.
+func (t *Time) UnmarshalJSON(data []byte) (err error) {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*t = Time{}
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err = json.Unmarshal(data, &s); err != nil {
+			return
+		}
+		if s == "" {
+			*t = Time{}
+			return nil
+		}
+		if *t, err = ParseTime(s); err != nil {
+			return &json.UnmarshalTypeError{Value: "string " + s, Type: timeType}
+		}
+		return nil
+	}
+	secs, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return &json.UnmarshalTypeError{Value: "number " + string(data), Type: timeType}
+	}
+	*t = Time{time.Unix(secs, 0), unixLayout}
+	return nil
+}
+
+// MarshalJSON encodes the timestamp in the same format it was decoded from,
+// or TimeLayout if it was not decoded from JSON. The zero Time is encoded
+// as null.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	switch t.layout {
+	case unixLayout:
+		return []byte(strconv.FormatInt(t.Unix(), 10)), nil
+	case "":
+		return json.Marshal(t.Format(TimeLayout))
+	}
+	return json.Marshal(t.Format(t.layout))
+}
+
+var timeType = reflect.TypeOf(Time{})
+
+// TimeOfDay is an hour of the day, as used by the sleep time account
+// settings. Valid is false when no time is set.
+type TimeOfDay struct {
+	Hour  int
+	Valid bool
+}
+
+// On returns the time of day t on the same date and in the same location
+// as day.
+func (t TimeOfDay) On(day time.Time) time.Time {
+	y, m, d := day.Date()
+	return time.Date(y, m, d, t.Hour, 0, 0, 0, day.Location())
+}
+
+// String returns the hour in the two-digit, 24-hour format expected by
+// the account/settings call, or "" if no time is set.
+func (t TimeOfDay) String() string {
+	if !t.Valid {
+		return ""
+	}
+	return fmt.Sprintf("%02d", t.Hour)
+}
+
+// UnmarshalJSON decodes an hour given as a number, as a string such as
+// "07" or "07:00", or null.
+func (t *TimeOfDay) UnmarshalJSON(data []byte) (err error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*t = TimeOfDay{}
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err = json.Unmarshal(data, &s); err != nil {
+			return
+		}
+		if s == "" {
+			*t = TimeOfDay{}
+			return nil
+		}
+		if i := strings.IndexByte(s, ':'); i >= 0 {
+			s = s[:i]
+		}
+	}
+	hour, err := strconv.Atoi(s)
+	if err != nil || hour < 0 || hour > 23 {
+		return &json.UnmarshalTypeError{Value: string(data), Type: reflect.TypeOf(*t)}
+	}
+	*t = TimeOfDay{hour, true}
+	return nil
+}
+
+// MarshalJSON encodes the hour as a number, or null if no time is set.
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.Itoa(t.Hour)), nil
+}
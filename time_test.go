@@ -0,0 +1,147 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+		ok   bool
+	}{
+		{"Wed Aug 27 13:08:45 +0000 2008", time.Date(2008, 8, 27, 13, 8, 45, 0, time.UTC), true},
+		{"2017-03-02T16:42:05Z", time.Date(2017, 3, 2, 16, 42, 5, 0, time.UTC), true},
+		{"2017-03-02T16:42:05.250Z", time.Date(2017, 3, 2, 16, 42, 5, 250e6, time.UTC), true},
+		{"2017-03-02T18:42:05+02:00", time.Date(2017, 3, 2, 16, 42, 5, 0, time.UTC), true},
+		{"2017-03-02", time.Date(2017, 3, 2, 0, 0, 0, 0, time.UTC), true},
+		{"yesterday", time.Time{}, false},
+		{"Wed Aug 27 2008", time.Time{}, false},
+		{"", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.in)
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseTime(%q) error = %v, want ok = %v", tt.in, err, tt.ok)
+			continue
+		}
+		if tt.ok && !got.Equal(tt.want) {
+			t.Errorf("ParseTime(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTimeJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+		ok   bool
+	}{
+		{`"Wed Aug 27 13:08:45 +0000 2008"`, time.Date(2008, 8, 27, 13, 8, 45, 0, time.UTC), true},
+		{`"2017-03-02T16:42:05Z"`, time.Date(2017, 3, 2, 16, 42, 5, 0, time.UTC), true},
+		{`1219842525`, time.Date(2008, 8, 27, 13, 8, 45, 0, time.UTC), true},
+		{`null`, time.Time{}, true},
+		{`""`, time.Time{}, true},
+		{`"not a time"`, time.Time{}, false},
+		{`12.5`, time.Time{}, false},
+		{`true`, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		var got Time
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err == nil) != tt.ok {
+			t.Errorf("Unmarshal(%s) error = %v, want ok = %v", tt.in, err, tt.ok)
+			continue
+		}
+		if !tt.ok {
+			if _, isType := err.(*json.UnmarshalTypeError); !isType {
+				t.Errorf("Unmarshal(%s) error = %T, want *json.UnmarshalTypeError", tt.in, err)
+			}
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.in, got, tt.want)
+		}
+		// timestamps are encoded back the way they were decoded
+		out, err := json.Marshal(got)
+		if err != nil {
+			t.Errorf("Marshal(%s) error = %v", tt.in, err)
+			continue
+		}
+		want := tt.in
+		if want == `""` {
+			want = `null`
+		}
+		if string(out) != want {
+			t.Errorf("Marshal(Unmarshal(%s)) = %s", tt.in, out)
+		}
+	}
+}
+
+func TestTimeMarshalDefaultLayout(t *testing.T) {
+	tm := Time{Time: time.Date(2008, 8, 27, 13, 8, 45, 0, time.UTC)}
+	out, err := json.Marshal(tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"Wed Aug 27 13:08:45 +0000 2008"`; string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+}
+
+func TestTimeOfDay(t *testing.T) {
+	tests := []struct {
+		in   string
+		want TimeOfDay
+		ok   bool
+	}{
+		{`7`, TimeOfDay{7, true}, true},
+		{`"07"`, TimeOfDay{7, true}, true},
+		{`"23:00"`, TimeOfDay{23, true}, true},
+		{`0`, TimeOfDay{0, true}, true},
+		{`null`, TimeOfDay{}, true},
+		{`""`, TimeOfDay{}, true},
+		{`24`, TimeOfDay{}, false},
+		{`-1`, TimeOfDay{}, false},
+		{`"noon"`, TimeOfDay{}, false},
+	}
+	for _, tt := range tests {
+		var got TimeOfDay
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err == nil) != tt.ok {
+			t.Errorf("Unmarshal(%s) error = %v, want ok = %v", tt.in, err, tt.ok)
+			continue
+		}
+		if tt.ok && got != tt.want {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	if s := (TimeOfDay{7, true}).String(); s != "07" {
+		t.Errorf("String() = %q, want %q", s, "07")
+	}
+	if s := (TimeOfDay{}).String(); s != "" {
+		t.Errorf("String() of unset time = %q, want empty", s)
+	}
+	for _, tod := range []TimeOfDay{{7, true}, {}} {
+		out, _ := json.Marshal(tod)
+		var back TimeOfDay
+		if err := json.Unmarshal(out, &back); err != nil || back != tod {
+			t.Errorf("round trip of %+v gave %+v, %v", tod, back, err)
+		}
+	}
+
+	loc := time.FixedZone("UTC-3", -3*3600)
+	day := time.Date(2017, 3, 2, 16, 42, 5, 0, loc)
+	if got, want := (TimeOfDay{7, true}).On(day), time.Date(2017, 3, 2, 7, 0, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("On = %v, want %v", got, want)
+	}
+}
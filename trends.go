@@ -0,0 +1,81 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import "encoding/json"
+
+// Groups trends functions
+type TrendsService struct {
+	*Client
+}
+
+// A trending topic
+type Trend struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	Url   string `json:"url"`
+	// Number of tweets about the trend in the last 24 hours, 0 if unknown
+	TweetVolume int `json:"tweet_volume"`
+	// Set for trends that are promoted by an advertiser
+	PromotedContent json.RawMessage `json:"promoted_content"`
+}
+
+// Reports whether the trend is promoted by an advertiser
+func (t *Trend) IsPromoted() bool {
+	return len(t.PromotedContent) > 0 && string(t.PromotedContent) != "null"
+}
+
+// Trending topics of a location
+type Trends struct {
+	Trends []Trend `json:"trends"`
+	// When the trends were computed
+	AsOf Time `json:"as_of"`
+	// When the trends were first requested
+	CreatedAt Time `json:"created_at"`
+	// Locations the trends are for. Only the name and woeid are set.
+	Locations TrendLocationList `json:"locations"`
+}
+
+// Returns the locations Twitter has trending topics for
+// See https://dev.twitter.com/docs/api/1.1/get/trends/available
+func (ts *TrendsService) Available() (locations *TrendLocationList, err error) {
+	locations = &TrendLocationList{}
+	err = ts.Call("GET", "trends/available", nil, locations)
+	return
+}
+
+// Returns the locations Twitter has trending topics for that are closest
+// to the given coordinates
+// See https://dev.twitter.com/docs/api/1.1/get/trends/closest
+func (ts *TrendsService) Closest(lat, long float64) (locations *TrendLocationList, err error) {
+	opts := NewOptionals()
+	opts.Set("lat", lat)
+	opts.Set("long", long)
+	locations = &TrendLocationList{}
+	err = ts.Call("GET", "trends/closest", opts, locations)
+	return
+}
+
+// Returns the top 50 trending topics for a location, given by its Yahoo!
+// Where On Earth ID (1 for worldwide trends). If excludeHashtags is set,
+// hashtags are left out.
+// See https://dev.twitter.com/docs/api/1.1/get/trends/place
+func (ts *TrendsService) Place(woeid int64, excludeHashtags bool) (trends *Trends, err error) {
+	opts := NewOptionals()
+	opts.Set("id", woeid)
+	if excludeHashtags {
+		opts.Set("exclude", "hashtags")
+	}
+	var ret []*Trends
+	if err = ts.Call("GET", "trends/place", opts, &ret); err != nil {
+		return nil, err
+	}
+	if len(ret) == 0 {
+		return &Trends{}, nil
+	}
+	return ret[0], nil
+}
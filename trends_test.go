@@ -0,0 +1,110 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+const trendsPlaceJSON = `[{
+	"trends": [
+		{"name": "#GiftforaSoldier", "url": "http://twitter.com/search?q=%23GiftforaSoldier",
+		 "promoted_content": null, "query": "%23GiftforaSoldier", "tweet_volume": 18432},
+		{"name": "Sponsored", "url": "http://twitter.com/search?q=Sponsored",
+		 "promoted_content": {"id": 1}, "query": "Sponsored", "tweet_volume": null}
+	],
+	"as_of": "2017-02-08T16:18:18Z",
+	"created_at": "2017-02-08T16:10:33Z",
+	"locations": [{"name": "Worldwide", "woeid": 1}]
+}]`
+
+const trendLocationsJSON = `[{
+	"country": "Sweden", "countryCode": "SE", "name": "Sweden", "parentid": 1,
+	"placeType": {"code": 12, "name": "Country"},
+	"url": "http://where.yahooapis.com/v1/place/23424954", "woeid": 23424954
+}]`
+
+func TestTrendsPlace(t *testing.T) {
+	var query string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/trends/place.json" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		w.Write([]byte(trendsPlaceJSON))
+	})
+	trends, err := c.Trends.Place(1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "exclude=hashtags&id=1" {
+		t.Errorf("query = %s", query)
+	}
+	if len(trends.Trends) != 2 {
+		t.Fatalf("got %d trends", len(trends.Trends))
+	}
+	first, promoted := trends.Trends[0], trends.Trends[1]
+	if first.Name != "#GiftforaSoldier" || first.TweetVolume != 18432 || first.IsPromoted() {
+		t.Errorf("first trend = %+v", first)
+	}
+	if promoted.TweetVolume != 0 || !promoted.IsPromoted() {
+		t.Errorf("promoted trend = %+v", promoted)
+	}
+	if !trends.AsOf.Equal(time.Date(2017, 2, 8, 16, 18, 18, 0, time.UTC)) ||
+		!trends.CreatedAt.Equal(time.Date(2017, 2, 8, 16, 10, 33, 0, time.UTC)) {
+		t.Errorf("as of %v, created at %v", trends.AsOf, trends.CreatedAt)
+	}
+	if len(trends.Locations) != 1 || trends.Locations[0].Woeid != 1 || trends.Locations[0].Name != "Worldwide" {
+		t.Errorf("locations = %+v", trends.Locations)
+	}
+
+	if _, err = c.Trends.Place(23424954, false); err != nil {
+		t.Fatal(err)
+	}
+	if query != "id=23424954" {
+		t.Errorf("query without excluding hashtags = %s", query)
+	}
+}
+
+func TestTrendsPlaceEmpty(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	})
+	trends, err := c.Trends.Place(1, false)
+	if err != nil || trends == nil || len(trends.Trends) != 0 {
+		t.Errorf("Place = %+v, %v; want no trends", trends, err)
+	}
+}
+
+func TestTrendLocations(t *testing.T) {
+	var path, query string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.RawQuery
+		w.Write([]byte(trendLocationsJSON))
+	})
+	check := func(name string, locations *TrendLocationList, err error, wantPath, wantQuery string) {
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if path != wantPath || query != wantQuery {
+			t.Errorf("%s requested %s?%s", name, path, query)
+		}
+		if len(*locations) != 1 {
+			t.Fatalf("%s: got %d locations", name, len(*locations))
+		}
+		l := (*locations)[0]
+		if l.Woeid != 23424954 || l.ParentId != 1 || l.CountryCode != "SE" || l.PlaceType.Code != 12 || l.PlaceType.Name != "Country" {
+			t.Errorf("%s: location = %+v", name, l)
+		}
+	}
+	locations, err := c.Trends.Available()
+	check("Available", locations, err, "/trends/available.json", "")
+	locations, err = c.Trends.Closest(59.33, 18.06)
+	check("Closest", locations, err, "/trends/closest.json", "lat=59.33&long=18.06")
+}
@@ -8,11 +8,11 @@ package tweetlib
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
-	"reflect"
+	"strings"
 )
 
 type TweetsService struct {
@@ -22,45 +22,151 @@ type TweetsService struct {
 // Holds a single tweet. Depending on the API call used, this
 // struct may or may not be fully populated.
 type Tweet struct {
-	Contributors        string `json:"contributors"`
-	User                *User  `json:"user"`
-	Truncated           bool   `json:"truncated"`
-	Text                string `json:"text"`
-	InReplyToScreenName string `json:"in_reply_to_screen_name"`
-	RetweetCount        int64  `json:"retweet_count"`
-	Entities            struct {
-		Urls []struct {
-			DisplayUrl  string  `json:"display_url"`
-			Indices     []int64 `json:"indices"`
-			Url         string  `json:"url"`
-			ExpandedUrl string  `json:"expanded_url"`
-		} `json:"urls"`
-		Hashtags []struct {
-			Text    string  `json:"text"`
-			Indices []int64 `json:"indices"`
-		} `json:"hashtags"`
-		UserMentions []struct {
-			ScreenName string  `json:"screen_name"`
-			Name       string  `json:"name"`
-			Indices    []int64 `json:"indices"`
-			IdStr      string  `json:"id_str"`
-			Id         int64   `json:"id"`
-		} `json:"user_mentions"`
-	} `json:"entities"`
-	Geo                  string `json:"geo"`
-	InReplyToUserId      int64  `json:"in_reply_to_user_id"`
-	IdStr                string `json:"id_str"`
-	CreatedAt            string `json:"created_at"`
-	Source               string `json:"source"`
-	Id                   int64  `json:"id"`
-	InReplyToStatusId    string `json:"in_reply_to_status_id"`
-	PossiblySensitive    bool   `json:"possibly_sensitive"`
-	Retweeted            bool   `json:"retweeted"`
-	InReplyToUserIdStr   string `json:"in_reply_to_user_id_str"`
-	Coordinates          string `json:"coordinates"`
-	Favorited            bool   `json:"favorited"`
-	Place                string `json:"place"`
-	InReplyToStatusIdStr string `json:"in_reply_to_status_id_str"`
+	Contributors         []Contributor     `json:"contributors"`
+	User                 *User             `json:"user"`
+	Truncated            bool              `json:"truncated"`
+	Text                 string            `json:"text"`
+	FullText             string            `json:"full_text"`
+	DisplayTextRange     Indices           `json:"display_text_range"`
+	ExtendedTweet        *ExtendedTweet    `json:"extended_tweet"`
+	InReplyToScreenName  string            `json:"in_reply_to_screen_name"`
+	RetweetCount         int64             `json:"retweet_count"`
+	FavoriteCount        int64             `json:"favorite_count"`
+	QuoteCount           int64             `json:"quote_count"`
+	ReplyCount           int64             `json:"reply_count"`
+	Entities             Entities          `json:"entities"`
+	ExtendedEntities     *ExtendedEntities `json:"extended_entities"`
+	Geo                  *GeoPoint         `json:"geo"`
+	InReplyToUserId      ID                `json:"in_reply_to_user_id"`
+	IdStr                string            `json:"id_str"`
+	CreatedAt            Time              `json:"created_at"`
+	Source               string            `json:"source"`
+	Id                   ID                `json:"id"`
+	InReplyToStatusId    ID                `json:"in_reply_to_status_id"`
+	PossiblySensitive    bool              `json:"possibly_sensitive"`
+	Retweeted            bool              `json:"retweeted"`
+	InReplyToUserIdStr   string            `json:"in_reply_to_user_id_str"`
+	Coordinates          *Point            `json:"coordinates"`
+	Favorited            bool              `json:"favorited"`
+	Place                *Place            `json:"place"`
+	InReplyToStatusIdStr string            `json:"in_reply_to_status_id_str"`
+	Lang                 string            `json:"lang"`
+	FilterLevel          string            `json:"filter_level"`
+	WithheldCopyright    bool              `json:"withheld_copyright"`
+	WithheldInCountries  []string          `json:"withheld_in_countries"`
+	WithheldScope        string            `json:"withheld_scope"`
+	RetweetedStatus      *Tweet            `json:"retweeted_status"`
+	QuotedStatus         *Tweet            `json:"quoted_status"`
+	QuotedStatusId       ID                `json:"quoted_status_id"`
+	QuotedStatusIdStr    string            `json:"quoted_status_id_str"`
+	IsQuoteStatus        bool              `json:"is_quote_status"`
+	CurrentUserRetweet   *struct {
+		Id    ID     `json:"id"`
+		IdStr string `json:"id_str"`
+	} `json:"current_user_retweet"`
+
+	RawJSON
+}
+
+func (t *Tweet) UnmarshalJSON(data []byte) error {
+	type tweet Tweet
+	return t.decodeJSON(data, (*tweet)(t))
+}
+
+func (t Tweet) MarshalJSON() ([]byte, error) {
+	type tweet Tweet
+	return t.encodeJSON((*tweet)(&t))
+}
+
+// A user who contributed to a tweet on behalf of its author
+type Contributor struct {
+	Id         ID     `json:"id"`
+	IdStr      string `json:"id_str"`
+	ScreenName string `json:"screen_name"`
+}
+
+// Reports whether the tweet is a retweet of another one, held in
+// RetweetedStatus.
+func (t *Tweet) IsRetweet() bool {
+	return t.RetweetedStatus != nil
+}
+
+// Reports whether the tweet is a reply to another tweet.
+func (t *Tweet) IsReply() bool {
+	return t.InReplyToStatusId != 0 || t.InReplyToStatusIdStr != ""
+}
+
+// Reports whether the tweet quotes another one. QuotedStatus may be nil
+// even so, as Twitter omits it when the quoted tweet is unavailable.
+func (t *Tweet) IsQuote() bool {
+	return t.IsQuoteStatus || t.QuotedStatus != nil
+}
+
+// Returns the tweet that was retweeted if this is a retweet, or the tweet
+// itself otherwise. Use it to attribute a tweet to its original author.
+func (t *Tweet) Original() *Tweet {
+	if t.RetweetedStatus != nil {
+		return t.RetweetedStatus
+	}
+	return t
+}
+
+// Tweets longer than 140 characters that are not requested in extended mode
+// (streamed tweets, for instance) have a truncated Text and carry their
+// complete text and entities in this sub-object.
+type ExtendedTweet struct {
+	FullText         string            `json:"full_text"`
+	DisplayTextRange Indices           `json:"display_text_range"`
+	Entities         Entities          `json:"entities"`
+	ExtendedEntities *ExtendedEntities `json:"extended_entities"`
+}
+
+// Returns the complete text of the tweet along with the matching display
+// range and entities, regardless of whether the tweet was returned in
+// extended mode, in compatibility mode with an extended_tweet sub-object,
+// or is a plain 140 character tweet.
+func (t *Tweet) Extended() *ExtendedTweet {
+	if t.ExtendedTweet != nil && t.ExtendedTweet.FullText != "" {
+		return t.ExtendedTweet
+	}
+	et := &ExtendedTweet{
+		FullText:         t.FullText,
+		DisplayTextRange: t.DisplayTextRange,
+		Entities:         t.Entities,
+		ExtendedEntities: t.ExtendedEntities,
+	}
+	if et.FullText == "" {
+		et.FullText = t.Text
+	}
+	return et
+}
+
+// Returns the full text of the tweet without the leading @mentions of a
+// reply or the trailing link to attached media, that is, the text Twitter
+// displays. The HTML entities Twitter escapes &, < and > with are decoded.
+// See Tweet.Extended.
+func (t *Tweet) DisplayText() string {
+	et := t.Extended()
+	// the display range refers to the unescaped text
+	text := textUnescaper.Replace(et.FullText)
+	r := et.DisplayTextRange
+	if r.End() == 0 {
+		return text
+	}
+	return utf16Slice(text, r.Start(), r.End())
+}
+
+// Decodes the HTML entities in the text of tweets
+var textUnescaper = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">")
+
+// Returns every photo, video or animated GIF attached to the tweet. Extended
+// entities are used when available as Entities only holds the first media.
+func (t *Tweet) Media() []MediaEntity {
+	et := t.Extended()
+	if et.ExtendedEntities != nil && len(et.ExtendedEntities.Media) > 0 {
+		return et.ExtendedEntities.Media
+	}
+	return et.Entities.Media
 }
 
 // A list of tweets
@@ -73,15 +179,101 @@ type TweetMedia struct {
 	Data     []byte // Raw file data
 }
 
+// Options of the timeline methods: Mentions, UserTimeline, HomeTimeline and
+// RetweetsOfMe.
+//
+// Usage:
+//
+//	opts := &TimelineOptions{Count: 200, ExcludeReplies: true, IncludeRTs: Bool(false)}
+//	tweets, err := client.Tweets.UserTimeline("golang", opts.Optionals())
+type TimelineOptions struct {
+	// Number of tweets to try and retrieve, up to 200
+	Count int
+	// Only return tweets more recent than this one
+	SinceID ID
+	// Only return tweets older than or equal to this one
+	MaxID ID
+	// Only include the id of the author of each tweet
+	TrimUser bool
+	// Leave replies out. Only supported by UserTimeline and HomeTimeline.
+	ExcludeReplies bool
+	// Whether to include retweets. Only supported by UserTimeline.
+	IncludeRTs *bool
+	// Whether to include entities
+	IncludeEntities *bool
+	// Include the screen name of contributors rather than only their id
+	ContributorDetails bool
+}
+
+// Returns the optionals to pass to the timeline methods
+func (to *TimelineOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if to == nil {
+		return opts
+	}
+	opts.setInt("count", to.Count)
+	opts.setID("since_id", to.SinceID)
+	opts.setID("max_id", to.MaxID)
+	opts.setBool("trim_user", to.TrimUser)
+	opts.setBool("exclude_replies", to.ExcludeReplies)
+	opts.setBoolPtr("include_rts", to.IncludeRTs)
+	opts.setBoolPtr("include_entities", to.IncludeEntities)
+	opts.setBool("contributor_details", to.ContributorDetails)
+	return opts
+}
+
+// Options of the methods returning a single tweet: Get, Destroy and Retweet
+type TweetOptions struct {
+	// Only include the id of the author of the tweet
+	TrimUser bool
+	// Include the id of the authenticating user's retweet of the tweet, if
+	// any. Only supported by Get.
+	IncludeMyRetweet bool
+	// Whether to include entities. Only supported by Get.
+	IncludeEntities *bool
+	// Include the alternative text of media. Only supported by Get.
+	IncludeExtAltText bool
+}
+
+// Returns the optionals to pass to Get, Destroy and Retweet
+func (to *TweetOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if to == nil {
+		return opts
+	}
+	opts.setBool("trim_user", to.TrimUser)
+	opts.setBool("include_my_retweet", to.IncludeMyRetweet)
+	opts.setBoolPtr("include_entities", to.IncludeEntities)
+	opts.setBool("include_ext_alt_text", to.IncludeExtAltText)
+	return opts
+}
+
+// Options of Retweets
+type RetweetsOptions struct {
+	// Number of retweets to retrieve, up to 100
+	Count int
+	// Only include the id of the author of each retweet
+	TrimUser bool
+}
+
+// Returns the optionals to pass to Retweets
+func (ro *RetweetsOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if ro == nil {
+		return opts
+	}
+	opts.setInt("count", ro.Count)
+	opts.setBool("trim_user", ro.TrimUser)
+	return opts
+}
+
 // Returns the 20 (by default) most recent tweets containing a users's
 // @screen_name for the authenticating user.
 // THis method can only return up to 800 tweets (via the "count" optional
 // parameter.
 // See https://dev.twitter.com/docs/api/1.1/get/statuses/mentions_timeline
 func (tg *TweetsService) Mentions(opts *Optionals) (tweets *TweetList, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+	opts = opts.Clone()
 	tweets = &TweetList{}
 	err = tg.Call("GET", "statuses/mentions_timeline", opts, tweets)
 	return
@@ -91,10 +283,8 @@ func (tg *TweetsService) Mentions(opts *Optionals) (tweets *TweetList, err error
 // by the screen_name.
 // See https://dev.twitter.com/docs/api/1.1/get/statuses/user_timeline
 func (tg *TweetsService) UserTimeline(screenname string, opts *Optionals) (tweets *TweetList, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	opts.Add("screen_name", screenname)
+	opts = opts.Clone()
+	opts.Set("screen_name", screenname)
 	tweets = new(TweetList)
 	err = tg.Call("GET", "statuses/user_timeline", opts, tweets)
 	return
@@ -104,9 +294,7 @@ func (tg *TweetsService) UserTimeline(screenname string, opts *Optionals) (tweet
 // the authenticating user and the users they follow.
 // See https://dev.twitter.com/docs/api/1.1/get/statuses/home_timeline
 func (tg *TweetsService) HomeTimeline(opts *Optionals) (tweets *TweetList, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+	opts = opts.Clone()
 	tweets = new(TweetList)
 	err = tg.Call("GET", "statuses/home_timeline", opts, tweets)
 	return
@@ -116,43 +304,89 @@ func (tg *TweetsService) HomeTimeline(opts *Optionals) (tweets *TweetList, err e
 // authenticating user that have been retweeted by others.
 // See https://dev.twitter.com/docs/api/1.1/get/statuses/retweets_of_me
 func (tg *TweetsService) RetweetsOfMe(opts *Optionals) (tweets *TweetList, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+	opts = opts.Clone()
 	tweets = new(TweetList)
 	err = tg.Call("GET", "statuses/retweets_of_me", opts, tweets)
 	return
 }
 
+// Optional parameters for Tweets.Update
+//
+// Usage:
+//
+//	opts := &UpdateOptions{Location: NewPoint(37.78, -122.40)}
+//	tweet, err := client.Tweets.Update("Hello, world", opts.Optionals())
+type UpdateOptions struct {
+	// Location the tweet refers to
+	Location *Point
+	// Id of the place the tweet is sent from
+	PlaceId string
+	// Whether to put a pin on the exact coordinates the tweet is sent from
+	DisplayCoordinates bool
+	// Tweet the update replies to
+	InReplyToStatusID ID
+	// Let Twitter add the @mentions of the replied thread to the text
+	AutoPopulateReplyMetadata bool
+	// Users to leave out of the @mentions added by
+	// AutoPopulateReplyMetadata
+	ExcludeReplyUserIDs []ID
+	// URL of a tweet to quote or of a direct message deep link, that does
+	// not count towards the length of the text
+	AttachmentUrl string
+	// Whether the attached media or links may be sensitive
+	PossiblySensitive bool
+	// Only include the id of the author in the returned tweet
+	TrimUser bool
+	// Ids of up to 4 images, or 1 video or GIF, uploaded with the Media
+	// service to attach to the tweet
+	MediaIds []ID
+}
+
+// Returns the optionals to pass to Tweets.Update
+func (uo *UpdateOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if uo == nil {
+		return opts
+	}
+	if uo.Location != nil {
+		opts.Set("lat", uo.Location.Lat())
+		opts.Set("long", uo.Location.Long())
+	}
+	opts.setString("place_id", uo.PlaceId)
+	opts.setBool("display_coordinates", uo.DisplayCoordinates)
+	opts.setID("in_reply_to_status_id", uo.InReplyToStatusID)
+	opts.setBool("auto_populate_reply_metadata", uo.AutoPopulateReplyMetadata)
+	opts.setIDs("exclude_reply_user_ids", uo.ExcludeReplyUserIDs)
+	opts.setString("attachment_url", uo.AttachmentUrl)
+	opts.setBool("possibly_sensitive", uo.PossiblySensitive)
+	opts.setBool("trim_user", uo.TrimUser)
+	opts.setIDs("media_ids", uo.MediaIds)
+	return opts
+}
+
 // Update: posts a status update to Twitter
 // See https://dev.twitter.com/docs/api/1.1/post/statuses/update
 func (tg *TweetsService) Update(status string, opts *Optionals) (tweet *Tweet, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	opts.Add("status", status)
+	opts = opts.Clone()
+	opts.Set("status", status)
 	tweet = &Tweet{}
 	err = tg.Call("POST", "statuses/update", opts, tweet)
 	return tweet, err
 }
 
 // Returns up to 100 of the first retweets of a given tweet Id
-func (tg *TweetsService) Retweets(id int64, opts *Optionals) (tweets *TweetList, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+func (tg *TweetsService) Retweets(id ID, opts *Optionals) (tweets *TweetList, err error) {
+	opts = opts.Clone()
 	tweets = &TweetList{}
-	err = tg.Call("GET", fmt.Sprintf("statuses/retweets/%d", id), opts, tweets)
+	err = tg.Call("GET", fmt.Sprintf("statuses/retweets/%s", id), opts, tweets)
 	return
 }
 
 // Returns a single Tweet, specified by the id parameter.
 // The Tweet's author will also be embedded within the tweet.
-func (tg *TweetsService) Get(id int64, opts *Optionals) (tweet *Tweet, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	opts.Add("id", id)
+func (tg *TweetsService) Get(id ID, opts *Optionals) (tweet *Tweet, err error) {
+	opts = opts.Clone()
+	opts.Set("id", id)
 	tweet = &Tweet{}
 	err = tg.Call("GET", "statuses/show", opts, tweet)
 	return
@@ -161,38 +395,37 @@ func (tg *TweetsService) Get(id int64, opts *Optionals) (tweet *Tweet, err error
 // Destroys the status specified by the required ID parameter.
 // The authenticating user must be the author of the specified
 // status. returns the destroyed tweet if successful
-func (tg *TweetsService) Destroy(id int64, opts *Optionals) (tweet *Tweet, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	opts.Add("id", id)
+func (tg *TweetsService) Destroy(id ID, opts *Optionals) (tweet *Tweet, err error) {
+	opts = opts.Clone()
+	opts.Set("id", id)
 	tweet = &Tweet{}
-	err = tg.Call("POST", fmt.Sprintf("statuses/destroy/%d", id), opts, tweet)
+	err = tg.Call("POST", fmt.Sprintf("statuses/destroy/%s", id), opts, tweet)
 	return tweet, err
 }
 
 // Retweets a tweet. Returns the original tweet with retweet details embedded.
-func (tg *TweetsService) Retweet(id int64, opts *Optionals) (tweet *Tweet, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	opts.Add("id", id)
+func (tg *TweetsService) Retweet(id ID, opts *Optionals) (tweet *Tweet, err error) {
+	opts = opts.Clone()
+	opts.Set("id", id)
 	tweet = &Tweet{}
-	err = tg.Call("POST", fmt.Sprintf("statuses/retweet/%d", id), opts, tweet)
+	err = tg.Call("POST", fmt.Sprintf("statuses/retweet/%s", id), opts, tweet)
 	return tweet, err
 }
 
 // Updates the authenticating user's current status and attaches media for
 // upload. In other words, it creates a Tweet with a picture attached.
+//
+// Deprecated: Twitter retired statuses/update_with_media. Upload the media
+// with Media.Upload or Media.ChunkedUpload and pass its id in
+// UpdateOptions.MediaIds instead.
 func (tg *TweetsService) UpdateWithMedia(status string, media *TweetMedia, opts *Optionals) (tweet *Tweet, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+	opts = opts.Clone()
 
 	body := bytes.NewBufferString("")
 	mp := multipart.NewWriter(body)
 	mp.WriteField("status", status)
-	for n, v := range opts.Values {
+	values := tg.params(opts)
+	for n, v := range values {
 		mp.WriteField(n, v[0])
 	}
 	writer, err := mp.CreateFormFile("media[]", media.Filename)
@@ -203,7 +436,7 @@ func (tg *TweetsService) UpdateWithMedia(status string, media *TweetMedia, opts
 	header := fmt.Sprintf("multipart/form-data;boundary=%v", mp.Boundary())
 	mp.Close()
 
-	endpoint := fmt.Sprintf("%s/statuses/update_with_media.json?%s", apiURL, opts.Values.Encode())
+	endpoint := fmt.Sprintf("%s/statuses/update_with_media.json?%s", tg.Endpoint, values.Encode())
 	req, _ := http.NewRequest("POST", endpoint, body)
 	req.Header.Set("Content-Type", header)
 	res, err := tg.client.Do(req)
@@ -214,11 +447,14 @@ func (tg *TweetsService) UpdateWithMedia(status string, media *TweetMedia, opts
 	if err = checkResponse(res); err != nil {
 		return
 	}
-	tweet = &Tweet{}
-	if err = json.NewDecoder(res.Body).Decode(tweet); err != nil &&
-		reflect.TypeOf(err) != reflect.TypeOf(&json.UnmarshalTypeError{}) {
+	rawJSON, err := ioutil.ReadAll(res.Body)
+	if err != nil {
 		return
 	}
+	tweet = &Tweet{}
+	if err = tg.decode("statuses/update_with_media", rawJSON, tweet); err != nil {
+		return nil, err
+	}
 	return tweet, nil
 
 }
@@ -229,13 +465,25 @@ type TweetSearchResults struct {
 	Results TweetList `json:"statuses"`
 	// Search metadata
 	Metadata TweetSearchMetadata `json:"search_metadata"`
+
+	RawJSON
+}
+
+func (sr *TweetSearchResults) UnmarshalJSON(data []byte) error {
+	type tweetSearchResults TweetSearchResults
+	return sr.decodeJSON(data, (*tweetSearchResults)(sr))
+}
+
+func (sr TweetSearchResults) MarshalJSON() ([]byte, error) {
+	type tweetSearchResults TweetSearchResults
+	return sr.encodeJSON((*tweetSearchResults)(&sr))
 }
 
 // When searching, Twitter returns this metadata
 // along with results
 type TweetSearchMetadata struct {
-	MaxId       int64   `json:"max_id"`
-	SinceId     int64   `json:"since_id"`
+	MaxId       ID      `json:"max_id"`
+	SinceId     ID      `json:"since_id"`
 	RefreshUrl  string  `json:"refresh_url"`
 	NextResults string  `json:"next_results"`
 	Count       int     `json:"count"`
@@ -243,16 +491,26 @@ type TweetSearchMetadata struct {
 	SinceIdStr  string  `json:"since_id_str"`
 	Query       string  `json:"query"`
 	MaxIdStr    string  `json:"max_id_str"`
+
+	RawJSON
+}
+
+func (sm *TweetSearchMetadata) UnmarshalJSON(data []byte) error {
+	type tweetSearchMetadata TweetSearchMetadata
+	return sm.decodeJSON(data, (*tweetSearchMetadata)(sm))
+}
+
+func (sm TweetSearchMetadata) MarshalJSON() ([]byte, error) {
+	type tweetSearchMetadata TweetSearchMetadata
+	return sm.encodeJSON((*tweetSearchMetadata)(&sm))
 }
 
 // Returns a collection of relevant Tweets matching a specified query.
 // See https://dev.twitter.com/docs/api/1.1/get/search/tweets
 // and also https://dev.twitter.com/docs/using-search
 func (tg *TweetsService) Tweets(q string, opts *Optionals) (searchResults *TweetSearchResults, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	opts.Add("q", q)
+	opts = opts.Clone()
+	opts.Set("q", q)
 	searchResults = &TweetSearchResults{}
 	err = tg.Call("GET", "search/tweets", opts, searchResults)
 	return
@@ -0,0 +1,126 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDisplayText(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{
+			"classic tweet",
+			`{"text": "Hello, world"}`,
+			"Hello, world",
+		},
+		{
+			"reply mentions and media link left out",
+			`{"full_text": "@bob hi there https://t.co/abc", "display_text_range": [5, 13]}`,
+			"hi there",
+		},
+		{
+			"escaped entities before the end of the range",
+			`{"full_text": "@bob hi &amp; there #go", "display_text_range": [5, 19]}`,
+			"hi & there #go",
+		},
+		{
+			"escaped entities without a range",
+			`{"full_text": "1 &lt; 2 &gt; 0"}`,
+			"1 < 2 > 0",
+		},
+		{
+			"astral characters count as two code units",
+			`{"full_text": "@bob 🐱 &amp; 🐶 https://t.co/abc", "display_text_range": [5, 12]}`,
+			"🐱 & 🐶",
+		},
+		{
+			"compatibility mode",
+			`{"text": "truncated… https://t.co/xyz", "truncated": true,
+			  "extended_tweet": {"full_text": "@bob a &amp; b", "display_text_range": [5, 10]}}`,
+			"a & b",
+		},
+	}
+	for _, tt := range tests {
+		var tw Tweet
+		if err := json.Unmarshal([]byte(tt.json), &tw); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := tw.DisplayText(); got != tt.want {
+			t.Errorf("%s: DisplayText() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRetweetOfQuote(t *testing.T) {
+	var tw Tweet
+	err := json.Unmarshal([]byte(`{
+		"id": 3, "id_str": "3", "text": "RT @alice: so true https://t.co/q",
+		"user": {"id": 30, "screen_name": "carol"},
+		"is_quote_status": true,
+		"quoted_status_id": 1,
+		"retweeted_status": {
+			"id": 2, "id_str": "2", "text": "so true https://t.co/q",
+			"user": {"id": 20, "screen_name": "alice"},
+			"is_quote_status": true,
+			"quoted_status_id": 1,
+			"quoted_status": {
+				"id": 1, "id_str": "1", "text": "Go is fun",
+				"user": {"id": 10, "screen_name": "bob"},
+				"in_reply_to_status_id": 0
+			}
+		}
+	}`), &tw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tw.IsRetweet() || !tw.IsQuote() || tw.IsReply() {
+		t.Errorf("IsRetweet, IsQuote, IsReply = %v, %v, %v, want true, true, false",
+			tw.IsRetweet(), tw.IsQuote(), tw.IsReply())
+	}
+	orig := tw.Original()
+	if orig.Id != 2 || orig.User.ScreenName != "alice" {
+		t.Fatalf("Original() = tweet %d by %s, want tweet 2 by alice", orig.Id, orig.User.ScreenName)
+	}
+	if orig.IsRetweet() || !orig.IsQuote() {
+		t.Errorf("Original(): IsRetweet, IsQuote = %v, %v", orig.IsRetweet(), orig.IsQuote())
+	}
+	if q := orig.QuotedStatus; q == nil || q.Id != 1 || q.User.ScreenName != "bob" {
+		t.Errorf("Original().QuotedStatus = %+v, want tweet 1 by bob", q)
+	}
+	if q := orig.QuotedStatus; q.Original() != q || q.IsQuote() {
+		t.Errorf("quoted tweet: Original() is not itself or IsQuote() is true")
+	}
+}
+
+func TestTweetKinds(t *testing.T) {
+	tests := []struct {
+		json                  string
+		retweet, reply, quote bool
+	}{
+		{`{"id": 1}`, false, false, false},
+		{`{"in_reply_to_status_id": 5, "in_reply_to_status_id_str": "5"}`, false, true, false},
+		{`{"in_reply_to_status_id_str": "5"}`, false, true, false},
+		{`{"is_quote_status": true}`, false, false, true},
+		{`{"quoted_status": {"id": 2}}`, false, false, true},
+		{`{"retweeted_status": {"id": 2}}`, true, false, false},
+	}
+	for _, tt := range tests {
+		var tw Tweet
+		if err := json.Unmarshal([]byte(tt.json), &tw); err != nil {
+			t.Fatalf("%s: %v", tt.json, err)
+		}
+		if tw.IsRetweet() != tt.retweet || tw.IsReply() != tt.reply || tw.IsQuote() != tt.quote {
+			t.Errorf("%s: IsRetweet, IsReply, IsQuote = %v, %v, %v, want %v, %v, %v", tt.json,
+				tw.IsRetweet(), tw.IsReply(), tw.IsQuote(), tt.retweet, tt.reply, tt.quote)
+		}
+	}
+}
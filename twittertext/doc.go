@@ -0,0 +1,43 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package twittertext implements the twitter-text rules Twitter applies to the
+text of tweets, so that drafts can be checked and analyzed before they are
+posted.
+
+Counting the length of a tweet:
+
+	res := twittertext.ParseTweet("Hello, world! https://example.com/")
+	fmt.Printf("%d characters, valid: %v\n", res.WeightedLength, res.Valid)
+
+Twitter may change the length of t.co links. To count them as currently
+configured, build the config from Help.Configuration:
+
+	conf, _ := client.Help.Configuration()
+	err := twittertext.ValidateTweetWithConfig(status, twittertext.ConfigFromAPI(conf))
+
+Screen names, list references, hashtags and URLs can be checked on their own
+with IsValidUsername, IsValidList, IsValidHashtag and IsValidURL.
+
+Extracting entities gives the same values Twitter fills Tweet.Entities with,
+indices included:
+
+	ents := twittertext.ExtractEntities("@golang: #go1 is out! https://go.dev/")
+	for _, h := range ents.Hashtags {
+		fmt.Println(h.Text, h.Indices.Start(), h.Indices.End())
+	}
+
+Tweets fetched from the API can be rendered with their entities linked:
+
+	tweets, _ := client.Tweets.HomeTimeline(nil)
+	for _, t := range *tweets {
+		fmt.Println(twittertext.RenderHTML(t.Original()))
+	}
+
+See https://github.com/twitter/twitter-text
+*/
+package twittertext
@@ -0,0 +1,98 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package twittertext
+
+// Ranges of code points displayed as emoji by default
+var emojiPresentation = [][2]rune{
+	{0x231A, 0x231B}, {0x23E9, 0x23EC}, {0x23F0, 0x23F0}, {0x23F3, 0x23F3},
+	{0x25FD, 0x25FE}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267F, 0x267F},
+	{0x2693, 0x2693}, {0x26A1, 0x26A1}, {0x26AA, 0x26AB}, {0x26BD, 0x26BE},
+	{0x26C4, 0x26C5}, {0x26CE, 0x26CE}, {0x26D4, 0x26D4}, {0x26EA, 0x26EA},
+	{0x26F2, 0x26F3}, {0x26F5, 0x26F5}, {0x26FA, 0x26FA}, {0x26FD, 0x26FD},
+	{0x2705, 0x2705}, {0x270A, 0x270B}, {0x2728, 0x2728}, {0x274C, 0x274C},
+	{0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797},
+	{0x27B0, 0x27B0}, {0x27BF, 0x27BF}, {0x2B1B, 0x2B1C}, {0x2B50, 0x2B50},
+	{0x2B55, 0x2B55}, {0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF},
+	{0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F201, 0x1F202},
+	{0x1F21A, 0x1F21A}, {0x1F22F, 0x1F22F}, {0x1F232, 0x1F23A},
+	{0x1F250, 0x1F251}, {0x1F300, 0x1F64F}, {0x1F680, 0x1F6FF},
+	{0x1F7E0, 0x1F7EB}, {0x1F90C, 0x1F9FF}, {0x1FA70, 0x1FAFF},
+}
+
+// Ranges of code points displayed as text by default but as emoji when
+// followed by a variation selector or a skin tone modifier
+var emojiText = [][2]rune{
+	{0x00A9, 0x00A9}, {0x00AE, 0x00AE}, {0x203C, 0x203C}, {0x2049, 0x2049},
+	{0x2122, 0x2122}, {0x2139, 0x2139}, {0x2194, 0x2199}, {0x21A9, 0x21AA},
+	{0x2300, 0x23FF}, {0x24C2, 0x24C2}, {0x25AA, 0x25FE}, {0x2600, 0x27BF},
+	{0x2934, 0x2935}, {0x2B05, 0x2B55}, {0x3030, 0x3030}, {0x303D, 0x303D},
+	{0x3297, 0x3297}, {0x3299, 0x3299}, {0x1F170, 0x1F251},
+}
+
+func inRanges(r rune, ranges [][2]rune) bool {
+	for _, rg := range ranges {
+		if r >= rg[0] && r <= rg[1] {
+			return true
+		}
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool { return r >= 0x1F1E6 && r <= 0x1F1FF }
+
+func isSkinTone(r rune) bool { return r >= 0x1F3FB && r <= 0x1F3FF }
+
+func isTag(r rune) bool { return r >= 0xE0020 && r <= 0xE007F }
+
+func isKeycapBase(r rune) bool { return r == '#' || r == '*' || (r >= '0' && r <= '9') }
+
+// Reports whether r is displayed as an emoji when followed by next
+func isEmojiBase(r, next rune) bool {
+	return inRanges(r, emojiPresentation) ||
+		(inRanges(r, emojiText) && (next == 0xFE0F || isSkinTone(next)))
+}
+
+// Returns the number of code points making up the emoji that starts at i,
+// including modifiers, variation selectors and sequences joined by ZWJ,
+// or 0 if there is no emoji at i.
+func emojiLen(t *utext, i int) int {
+	r := t.at(i)
+	switch {
+	case isRegionalIndicator(r):
+		// flags are pairs of regional indicators
+		if isRegionalIndicator(t.at(i + 1)) {
+			return 2
+		}
+		return 0
+	case isKeycapBase(r):
+		j := i + 1
+		if t.at(j) == 0xFE0F {
+			j++
+		}
+		if t.at(j) == 0x20E3 {
+			return j + 1 - i
+		}
+		return 0
+	case !isEmojiBase(r, t.at(i+1)):
+		return 0
+	}
+	j := i + 1
+	for {
+		c := t.at(j)
+		switch {
+		case c == 0xFE0F || c == 0xFE0E || c == 0x20E3 || isSkinTone(c) || isTag(c):
+			j++
+		case c == 0x200D && isEmojiBase(t.at(j+1), t.at(j+2)):
+			j += 2
+		case c == 0x200D && inRanges(t.at(j+1), emojiText):
+			// e.g. the heart in "couple with heart"
+			j += 2
+		default:
+			return j - i
+		}
+	}
+}
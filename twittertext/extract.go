@@ -0,0 +1,334 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package twittertext
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/tweetlib.v2"
+)
+
+// Maximum lengths of a screen name and of a list slug
+const (
+	maxScreenNameLength = 20
+	maxListSlugLength   = 25
+)
+
+// Extracts the URLs, hashtags, mentions and cashtags of text, the way
+// Twitter fills the entities of a tweet. Entities that overlap an earlier
+// one (e.g. a hashtag that is part of a URL) are dropped. Indices are
+// expressed in UTF-16 code units.
+func ExtractEntities(text string) tweetlib.Entities {
+	t := newText(text)
+	type entity struct {
+		start, end int
+		add        func()
+	}
+	var all []entity
+	var ents tweetlib.Entities
+	for _, u := range extractURLs(t, true) {
+		u := u
+		all = append(all, entity{u.start, u.end, func() {
+			ents.Urls = append(ents.Urls, urlEntity(t, u))
+		}})
+	}
+	for _, h := range extractHashtags(t) {
+		h := h
+		all = append(all, entity{h.start, h.end, func() {
+			ents.Hashtags = append(ents.Hashtags, tweetlib.HashtagEntity{Text: h.text, Indices: h.indices(t)})
+		}})
+	}
+	for _, m := range extractMentions(t) {
+		m := m
+		all = append(all, entity{m.start, m.end, func() {
+			ents.UserMentions = append(ents.UserMentions, tweetlib.MentionEntity{ScreenName: m.text, Indices: m.indices(t)})
+		}})
+	}
+	for _, c := range extractCashtags(t) {
+		c := c
+		all = append(all, entity{c.start, c.end, func() {
+			ents.Symbols = append(ents.Symbols, tweetlib.SymbolEntity{Text: c.text, Indices: c.indices(t)})
+		}})
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].start < all[j].start })
+	end := 0
+	for _, e := range all {
+		if e.start < end {
+			continue
+		}
+		e.add()
+		end = e.end
+	}
+	return ents
+}
+
+// Extracts the URLs of text, with or without a protocol. Url and
+// ExpandedUrl both hold the URL as it appears in the text, and DisplayUrl
+// holds it without its protocol.
+func ExtractURLs(text string) (urls []tweetlib.URLEntity) {
+	t := newText(text)
+	for _, u := range extractURLs(t, true) {
+		urls = append(urls, urlEntity(t, u))
+	}
+	return
+}
+
+// Extracts the hashtags of text, leaving out those that are part of a URL
+func ExtractHashtags(text string) (hashtags []tweetlib.HashtagEntity) {
+	t := newText(text)
+	urls := extractURLs(t, true)
+	for _, h := range extractHashtags(t) {
+		if overlapsURL(h, urls) {
+			continue
+		}
+		hashtags = append(hashtags, tweetlib.HashtagEntity{Text: h.text, Indices: h.indices(t)})
+	}
+	return
+}
+
+// Extracts the @mentions of text
+func ExtractMentions(text string) (mentions []tweetlib.MentionEntity) {
+	t := newText(text)
+	for _, m := range extractMentions(t) {
+		mentions = append(mentions, tweetlib.MentionEntity{ScreenName: m.text, Indices: m.indices(t)})
+	}
+	return
+}
+
+// A mention of a user, or of one of their lists as in "@user/list"
+type MentionOrList struct {
+	ScreenName string
+	// Slug of the list, or "" for a mention of the user
+	ListSlug string
+	Indices  tweetlib.Indices
+}
+
+// Extracts the @mentions and @user/list references of text
+func ExtractMentionsOrLists(text string) (mentions []MentionOrList) {
+	t := newText(text)
+	for i := 0; i < len(t.runes); i++ {
+		if m, slug, ok := matchMentionOrList(t, i); ok {
+			mentions = append(mentions, MentionOrList{m.text, slug, m.indices(t)})
+			i = m.end - 1
+		}
+	}
+	return
+}
+
+// Extracts the $cashtags of text
+func ExtractCashtags(text string) (cashtags []tweetlib.SymbolEntity) {
+	t := newText(text)
+	for _, c := range extractCashtags(t) {
+		cashtags = append(cashtags, tweetlib.SymbolEntity{Text: c.text, Indices: c.indices(t)})
+	}
+	return
+}
+
+// Returns the screen name text is a reply to, that is, the one mentioned
+// at its very beginning, or "" if text is not a reply.
+func ExtractReplyScreenName(text string) string {
+	t := newText(text)
+	i := 0
+	for i < len(t.runes) && (unicode.IsSpace(t.runes[i]) || t.runes[i] == 0x200E) {
+		i++
+	}
+	if m, ok := matchMention(t, i); ok && m.start == i {
+		return m.text
+	}
+	return ""
+}
+
+// An entity found in a text. start and end are code point indices and
+// text holds the entity without its leading sign.
+type match struct {
+	start, end int
+	text       string
+}
+
+// Returns the UTF-16 indices of the match
+func (m match) indices(t *utext) tweetlib.Indices {
+	return tweetlib.Indices{t.offsets[m.start], t.offsets[m.end]}
+}
+
+func urlEntity(t *utext, u urlMatch) tweetlib.URLEntity {
+	s := t.slice(u.start, u.end)
+	return tweetlib.URLEntity{
+		Url:         s,
+		DisplayUrl:  t.slice(u.start+protocolLen(t, u.start), u.end),
+		ExpandedUrl: s,
+		Indices:     tweetlib.Indices{t.offsets[u.start], t.offsets[u.end]},
+	}
+}
+
+func overlapsURL(m match, urls []urlMatch) bool {
+	for _, u := range urls {
+		if m.start < u.end && u.start < m.end {
+			return true
+		}
+	}
+	return false
+}
+
+func isAtSign(c rune) bool { return c == '@' || c == '＠' }
+
+func isHashSign(c rune) bool { return c == '#' || c == '＃' }
+
+func isScreenNameChar(c rune) bool { return c == '_' || isASCIIAlnum(c) }
+
+func isListSlugChar(c rune) bool { return c == '-' || isScreenNameChar(c) }
+
+func extractMentions(t *utext) (mentions []match) {
+	for i := 0; i < len(t.runes); i++ {
+		if m, ok := matchMention(t, i); ok {
+			mentions = append(mentions, m)
+			i = m.end - 1
+		}
+	}
+	return
+}
+
+// Matches a mention whose at sign is at i
+func matchMention(t *utext, i int) (m match, ok bool) {
+	m, slug, ok := matchMentionOrList(t, i)
+	// lists (@user/list) are not mentions
+	return m, ok && slug == ""
+}
+
+// Matches a mention or a list whose at sign is at i. slug is the list
+// slug, or "" for a mention.
+func matchMentionOrList(t *utext, i int) (m match, slug string, ok bool) {
+	if !isAtSign(t.at(i)) || !isValidMentionPreceding(t, i) {
+		return m, "", false
+	}
+	j := i + 1
+	for j < len(t.runes) && j-i-1 < maxScreenNameLength && isScreenNameChar(t.runes[j]) {
+		j++
+	}
+	if j == i+1 {
+		return m, "", false
+	}
+	m = match{i, j, t.slice(i+1, j)}
+	if t.at(j) == '/' && isASCIILetter(t.at(j+1)) {
+		k := j + 2
+		for k < len(t.runes) && k-j-1 < maxListSlugLength && isListSlugChar(t.runes[k]) {
+			k++
+		}
+		slug = t.slice(j+1, k)
+		j = k
+		m.end = k
+	}
+	// what looks like an email address or a URL is not a mention
+	switch c := t.at(j); {
+	case isAtSign(c), isLatinAccent(c):
+		return m, "", false
+	case c == ':' && t.at(j+1) == '/' && t.at(j+2) == '/':
+		return m, "", false
+	}
+	return m, slug, true
+}
+
+// Reports whether a mention may start at i. Mentions cannot be preceded by
+// a letter, a digit or a few symbols, except when following "RT" as in
+// "RT@user".
+func isValidMentionPreceding(t *utext, i int) bool {
+	c := t.at(i - 1)
+	if c < 0 || !(isScreenNameChar(c) || strings.ContainsRune("!#$%&*@＠", c)) {
+		return true
+	}
+	if i >= 2 && strings.EqualFold(t.slice(i-2, i), "rt") {
+		p := t.at(i - 3)
+		return p < 0 || !(isASCIIAlnum(p) || strings.ContainsRune("_+~.-", p))
+	}
+	return false
+}
+
+// Characters allowed in hashtags besides letters, marks and digits
+const hashtagSpecialChars = "_‌‍꙾־׳״～〜゛゜゠・〃་༌·"
+
+func isHashtagAlpha(c rune) bool {
+	return unicode.IsLetter(c) || unicode.Is(unicode.M, c)
+}
+
+func isHashtagChar(c rune) bool {
+	return isHashtagAlpha(c) || unicode.Is(unicode.Nd, c) || (c >= 0 && strings.ContainsRune(hashtagSpecialChars, c))
+}
+
+func extractHashtags(t *utext) (hashtags []match) {
+	for i := 0; i < len(t.runes); i++ {
+		c := t.runes[i]
+		if !isHashSign(c) {
+			continue
+		}
+		if p := t.at(i - 1); p == '&' || (p >= 0 && isHashtagChar(p)) {
+			continue
+		}
+		// keycap emoji such as #️⃣ are not hashtags
+		if n := t.at(i + 1); n == 0xFE0F || n == 0x20E3 {
+			continue
+		}
+		j := i + 1
+		alpha := false
+		for j < len(t.runes) && isHashtagChar(t.runes[j]) {
+			alpha = alpha || isHashtagAlpha(t.runes[j])
+			j++
+		}
+		if !alpha {
+			continue
+		}
+		if n := t.at(j); isHashSign(n) || (n == ':' && t.at(j+1) == '/' && t.at(j+2) == '/') {
+			i = j - 1
+			continue
+		}
+		hashtags = append(hashtags, match{i, j, t.slice(i+1, j)})
+		i = j - 1
+	}
+	return
+}
+
+func extractCashtags(t *utext) (cashtags []match) {
+	for i := 0; i < len(t.runes); i++ {
+		if t.runes[i] != '$' {
+			continue
+		}
+		if p := t.at(i - 1); p >= 0 && !unicode.IsSpace(p) {
+			continue
+		}
+		// one to six letters, optionally followed by a dot or an
+		// underscore and one or two letters, as in $BRK.A
+		j := i + 1
+		for j < len(t.runes) && j-i <= 6 && isASCIILetter(t.runes[j]) {
+			j++
+		}
+		if j == i+1 {
+			continue
+		}
+		if s := t.at(j); (s == '.' || s == '_') && isASCIILetter(t.at(j+1)) {
+			k := j + 1
+			for k < len(t.runes) && k-j <= 2 && isASCIILetter(t.runes[k]) {
+				k++
+			}
+			j = k
+		}
+		if n := t.at(j); n >= 0 && !unicode.IsSpace(n) && !isPunct(n) {
+			continue
+		}
+		cashtags = append(cashtags, match{i, j, t.slice(i+1, j)})
+		i = j - 1
+	}
+	return
+}
+
+// Reports whether c is punctuation, including ASCII symbols such as "$"
+func isPunct(c rune) bool {
+	return unicode.IsPunct(c) || (c < 0x80 && unicode.IsSymbol(c))
+}
+
+func isASCIILetter(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
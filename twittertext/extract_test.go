@@ -0,0 +1,247 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package twittertext
+
+import (
+	"slices"
+	"testing"
+
+	"gopkg.in/tweetlib.v2"
+)
+
+type extractTest struct {
+	Description string
+	Text        string
+	Expected    []string
+}
+
+// An entity as listed by the *_with_indices conformance tests
+type indexedEntity struct {
+	ScreenName string `yaml:"screen_name"`
+	ListSlug   string `yaml:"list_slug"`
+	Url        string `yaml:"url"`
+	Hashtag    string `yaml:"hashtag"`
+	Cashtag    string `yaml:"cashtag"`
+	Indices    tweetlib.Indices
+}
+
+type indexedExtractTest struct {
+	Description string
+	Text        string
+	Expected    []indexedEntity
+}
+
+type extractTests struct {
+	Tests struct {
+		Mentions            []extractTest
+		MentionsWithIndices []indexedExtractTest `yaml:"mentions_with_indices"`
+		MentionsOrLists     []indexedExtractTest `yaml:"mentions_or_lists_with_indices"`
+		Replies             []struct {
+			Description string
+			Text        string
+			Expected    string
+		}
+		Urls                []extractTest
+		UrlsWithIndices     []indexedExtractTest `yaml:"urls_with_indices"`
+		Hashtags            []extractTest
+		HashtagsWithIndices []indexedExtractTest `yaml:"hashtags_with_indices"`
+		Cashtags            []extractTest
+		CashtagsWithIndices []indexedExtractTest `yaml:"cashtags_with_indices"`
+	}
+}
+
+// Extract the entities of text in the form of the conformance tests
+func mentionEntities(text string) (got []indexedEntity) {
+	for _, m := range ExtractMentions(text) {
+		got = append(got, indexedEntity{ScreenName: m.ScreenName, Indices: m.Indices})
+	}
+	return
+}
+
+func mentionOrListEntities(text string) (got []indexedEntity) {
+	for _, m := range ExtractMentionsOrLists(text) {
+		e := indexedEntity{ScreenName: m.ScreenName, Indices: m.Indices}
+		if m.ListSlug != "" {
+			// the conformance tests keep the leading slash
+			e.ListSlug = "/" + m.ListSlug
+		}
+		got = append(got, e)
+	}
+	return
+}
+
+func urlEntities(text string) (got []indexedEntity) {
+	for _, u := range ExtractURLs(text) {
+		got = append(got, indexedEntity{Url: u.Url, Indices: u.Indices})
+	}
+	return
+}
+
+func hashtagEntities(text string) (got []indexedEntity) {
+	for _, h := range ExtractHashtags(text) {
+		got = append(got, indexedEntity{Hashtag: h.Text, Indices: h.Indices})
+	}
+	return
+}
+
+func cashtagEntities(text string) (got []indexedEntity) {
+	for _, c := range ExtractCashtags(text) {
+		got = append(got, indexedEntity{Cashtag: c.Text, Indices: c.Indices})
+	}
+	return
+}
+
+func TestExtract(t *testing.T) {
+	var conf extractTests
+	loadConformance(t, "extract.yml", &conf)
+	tests := conf.Tests
+
+	for _, set := range []struct {
+		name    string
+		tests   []extractTest
+		extract func(string) []indexedEntity
+		text    func(indexedEntity) string
+	}{
+		{"mentions", tests.Mentions, mentionEntities, func(e indexedEntity) string { return e.ScreenName }},
+		{"urls", tests.Urls, urlEntities, func(e indexedEntity) string { return e.Url }},
+		{"hashtags", tests.Hashtags, hashtagEntities, func(e indexedEntity) string { return e.Hashtag }},
+		{"cashtags", tests.Cashtags, cashtagEntities, func(e indexedEntity) string { return e.Cashtag }},
+	} {
+		if len(set.tests) == 0 {
+			t.Errorf("%s: no tests loaded", set.name)
+		}
+		for _, tt := range set.tests {
+			var got []string
+			for _, e := range set.extract(tt.Text) {
+				got = append(got, set.text(e))
+			}
+			if !slices.Equal(got, tt.Expected) {
+				t.Errorf("%s: %s: got %q, want %q", set.name, tt.Description, got, tt.Expected)
+			}
+		}
+	}
+
+	for _, set := range []struct {
+		name    string
+		tests   []indexedExtractTest
+		extract func(string) []indexedEntity
+	}{
+		{"mentions_with_indices", tests.MentionsWithIndices, mentionEntities},
+		{"mentions_or_lists_with_indices", tests.MentionsOrLists, mentionOrListEntities},
+		{"urls_with_indices", tests.UrlsWithIndices, urlEntities},
+		{"hashtags_with_indices", tests.HashtagsWithIndices, hashtagEntities},
+		{"cashtags_with_indices", tests.CashtagsWithIndices, cashtagEntities},
+	} {
+		if len(set.tests) == 0 {
+			t.Errorf("%s: no tests loaded", set.name)
+		}
+		for _, tt := range set.tests {
+			if got := set.extract(tt.Text); !slices.Equal(got, tt.Expected) {
+				t.Errorf("%s: %s:\ngot  %+v\nwant %+v", set.name, tt.Description, got, tt.Expected)
+			}
+		}
+	}
+
+	if len(tests.Replies) == 0 {
+		t.Error("replies: no tests loaded")
+	}
+	for _, tt := range tests.Replies {
+		if got := ExtractReplyScreenName(tt.Text); got != tt.Expected {
+			t.Errorf("replies: %s: got %q, want %q", tt.Description, got, tt.Expected)
+		}
+	}
+}
+
+// Mentions may follow "RT" but no other letter, including at the start of
+// the text where there is no room for "RT" before the at sign
+func TestMentionPreceding(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"@user", []string{"user"}},
+		{"T@user", nil},
+		{"t@user", nil},
+		{"RT@user", []string{"user"}},
+		{"rt@user hi", []string{"user"}},
+		{"ART@user", nil},
+		{"a.RT@user", nil},
+		{" RT@user", []string{"user"}},
+		{"#@user", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, m := range ExtractMentions(tt.text) {
+			got = append(got, m.ScreenName)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ExtractMentions(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+// Rules twitter-text changed after the snapshot of the conformance file
+// in testdata, see testdata/README
+func TestExtractLaterRules(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []indexedEntity
+		got  func(string) []indexedEntity
+	}{
+		{
+			"URL after a left-to-right mark",
+			"\u200ehttp://example.com\u200f",
+			[]indexedEntity{{Url: "http://example.com", Indices: tweetlib.Indices{1, 19}}},
+			urlEntities,
+		},
+		{
+			"URL after a right-to-left embedding",
+			"\u202bhttp://example.com \u2067example.org\u2069",
+			[]indexedEntity{
+				{Url: "http://example.com", Indices: tweetlib.Indices{1, 19}},
+				{Url: "example.org", Indices: tweetlib.Indices{21, 32}},
+			},
+			urlEntities,
+		},
+		{
+			"t.co URL with params",
+			"see https://t.co/abcde?amp=1&q=x.",
+			[]indexedEntity{{Url: "https://t.co/abcde?amp=1&q=x", Indices: tweetlib.Indices{4, 32}}},
+			urlEntities,
+		},
+		{
+			"t.co URL with more than one path segment",
+			"see https://t.co/abcde/more?amp=1",
+			[]indexedEntity{{Url: "https://t.co/abcde", Indices: tweetlib.Indices{4, 22}}},
+			urlEntities,
+		},
+		{
+			"hashtag of letters outside the basic multilingual plane",
+			"#\U00020000\U0002000b #tag",
+			[]indexedEntity{
+				{Hashtag: "\U00020000\U0002000b", Indices: tweetlib.Indices{0, 5}},
+				{Hashtag: "tag", Indices: tweetlib.Indices{6, 10}},
+			},
+			hashtagEntities,
+		},
+		{
+			"hashtag after an emoji",
+			"\U0001f431#cat \U0001f431 #dog",
+			[]indexedEntity{
+				{Hashtag: "cat", Indices: tweetlib.Indices{2, 6}},
+				{Hashtag: "dog", Indices: tweetlib.Indices{10, 14}},
+			},
+			hashtagEntities,
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.got(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("%s:\ngot  %+v\nwant %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+
+// Generates tlds.go from the twitter-text conformance file
+// testdata/tlds.yml, so that the TLDs recognized are the ones the
+// conformance tests check. Run with go generate after updating the file.
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+type tldTest struct {
+	Text string `yaml:"text"`
+}
+
+func main() {
+	data, err := os.ReadFile("testdata/tlds.yml")
+	if err != nil {
+		log.Fatal(err)
+	}
+	var conf struct {
+		Tests struct {
+			Country []tldTest `yaml:"country"`
+			Generic []tldTest `yaml:"generic"`
+		} `yaml:"tests"`
+	}
+	if err := yaml.Unmarshal(data, &conf); err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by gen_tlds.go from testdata/tlds.yml; DO NOT EDIT.
+
+package twittertext
+
+// Top-level domains recognized when extracting URLs, as listed by
+// twitter-text. Country code TLDs are treated specially for URLs
+// without a protocol.
+const (
+	// Country code top-level domains
+	countryTLDs = `)
+	writeList(&buf, conf.Tests.Country)
+	buf.WriteString(`
+
+	// Generic top-level domains
+	genericTLDs = `)
+	writeList(&buf, conf.Tests.Generic)
+	buf.WriteString("\n)\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("tlds.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Writes the TLDs of tests as a concatenation of space separated string
+// literals wrapped at about 80 columns
+func writeList(buf *bytes.Buffer, tests []tldTest) {
+	const prefix = "https://twitter."
+	buf.WriteString(`"" +`)
+	line := ""
+	for i, t := range tests {
+		if !strings.HasPrefix(t.Text, prefix) {
+			log.Fatalf("unexpected test text %q", t.Text)
+		}
+		tld := t.Text[len(prefix):]
+		if i < len(tests)-1 {
+			tld += " "
+		}
+		if line != "" && len([]rune(line+tld)) > 70 {
+			buf.WriteString("\n\t\t\"" + line + "\" +")
+			line = ""
+		}
+		line += tld
+	}
+	buf.WriteString("\n\t\t\"" + line + "\"")
+}
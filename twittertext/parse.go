@@ -0,0 +1,187 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package twittertext
+
+import (
+	"errors"
+
+	"golang.org/x/text/unicode/norm"
+	"gopkg.in/tweetlib.v2"
+)
+
+// Rules used to count the length of a tweet
+// See https://developer.twitter.com/en/docs/counting-characters
+type Config struct {
+	// Maximum weighted length of a tweet
+	MaxWeightedTweetLength int
+	// Weights are expressed in units of 1/Scale character
+	Scale int
+	// Weight of code points that are not in any of Ranges
+	DefaultWeight int
+	// Whether emoji sequences count as a single code point of
+	// DefaultWeight
+	EmojiParsingEnabled bool
+	// Length every URL is counted as, since Twitter wraps them with t.co
+	TransformedUrlLength int
+	// Weights of ranges of code points
+	Ranges []WeightedRange
+}
+
+// Weight of the code points between Start and End inclusive
+type WeightedRange struct {
+	Start, End rune
+	Weight     int
+}
+
+// The rules in effect since twitter-text 3.0: 280 characters, where code
+// points from the CJK scripts and emoji count double.
+var DefaultConfig = &Config{
+	MaxWeightedTweetLength: 280,
+	Scale:                  100,
+	DefaultWeight:          200,
+	EmojiParsingEnabled:    true,
+	TransformedUrlLength:   23,
+	Ranges: []WeightedRange{
+		{0, 4351, 100},
+		{8192, 8205, 100},
+		{8208, 8223, 100},
+		{8242, 8247, 100},
+	},
+}
+
+// Returns a copy of DefaultConfig using the t.co URL length reported by
+// Help.Configuration, the longer of ShortUrlLength and
+// ShortUrlLengthHttps. The default is kept if neither is set.
+func ConfigFromAPI(conf *tweetlib.Configuration) *Config {
+	c := *DefaultConfig
+	n := conf.ShortUrlLengthHttps
+	if conf.ShortUrlLength > n {
+		n = conf.ShortUrlLength
+	}
+	if n > 0 {
+		c.TransformedUrlLength = n
+	}
+	return &c
+}
+
+// Returns the weight of code point r
+func (c *Config) weight(r rune) int {
+	for _, rg := range c.Ranges {
+		if r >= rg.Start && r <= rg.End {
+			return rg.Weight
+		}
+	}
+	return c.DefaultWeight
+}
+
+// Result of parsing a tweet. Ranges are inclusive and expressed in UTF-16
+// code units.
+type ParseResults struct {
+	// Length of the tweet as counted by Twitter
+	WeightedLength int
+	// WeightedLength relative to the maximum, in thousandths
+	Permillage int
+	// Whether the tweet can be posted
+	Valid bool
+	// Range of the whole text
+	DisplayRangeStart, DisplayRangeEnd int
+	// Range of the text that fits within the limit
+	ValidRangeStart, ValidRangeEnd int
+}
+
+// Code points that cannot appear in a tweet
+func isInvalidChar(r rune) bool {
+	return r == 0xFFFE || r == 0xFEFF || r == 0xFFFF
+}
+
+// Parses text with DefaultConfig. See ParseTweetWithConfig.
+func ParseTweet(text string) ParseResults {
+	return ParseTweetWithConfig(text, DefaultConfig)
+}
+
+// Counts the weighted length of text as Twitter does: the text is NFC
+// normalized, every URL counts as TransformedUrlLength characters, emoji
+// count as a single code point and every other code point counts for
+// its weight.
+func ParseTweetWithConfig(text string, config *Config) (res ParseResults) {
+	t := newText(norm.NFC.String(text))
+	original := newText(text).len16()
+	if original > 0 {
+		res.DisplayRangeEnd = original - 1
+	}
+	if len(t.runes) == 0 {
+		return
+	}
+
+	urlAt := make(map[int]int)
+	for _, u := range extractURLs(t, true) {
+		urlAt[u.start] = u.end
+	}
+	max := config.MaxWeightedTweetLength * config.Scale
+	weighted := 0
+	valid := true
+	validEnd := -1
+	for i := 0; i < len(t.runes); {
+		next := i + 1
+		if end, ok := urlAt[i]; ok {
+			weighted += config.TransformedUrlLength * config.Scale
+			next = end
+		} else if n := emojiLen(t, i); config.EmojiParsingEnabled && n > 0 {
+			weighted += config.DefaultWeight
+			next = i + n
+		} else {
+			weighted += config.weight(t.runes[i])
+		}
+		for _, r := range t.runes[i:next] {
+			valid = valid && !isInvalidChar(r)
+		}
+		if valid && weighted <= max {
+			validEnd = t.offsets[next] - 1
+		}
+		i = next
+	}
+
+	res.WeightedLength = weighted / config.Scale
+	res.Permillage = res.WeightedLength * 1000 / config.MaxWeightedTweetLength
+	res.Valid = valid && weighted <= max
+	if validEnd >= 0 {
+		// map back to the text before normalization
+		res.ValidRangeEnd = validEnd + original - t.len16()
+	}
+	return
+}
+
+// Errors returned by ValidateTweet
+var (
+	ErrEmptyTweet        = errors.New("tweet is empty")
+	ErrTweetTooLong      = errors.New("tweet is too long")
+	ErrInvalidCharacters = errors.New("tweet contains invalid characters")
+)
+
+// Checks whether text can be posted as a tweet with DefaultConfig. It
+// returns nil if it can, and ErrEmptyTweet, ErrTweetTooLong or
+// ErrInvalidCharacters otherwise.
+func ValidateTweet(text string) error {
+	return ValidateTweetWithConfig(text, DefaultConfig)
+}
+
+// Checks whether text can be posted as a tweet with the given config.
+// See ValidateTweet.
+func ValidateTweetWithConfig(text string, config *Config) error {
+	if text == "" {
+		return ErrEmptyTweet
+	}
+	for _, r := range text {
+		if isInvalidChar(r) {
+			return ErrInvalidCharacters
+		}
+	}
+	if res := ParseTweetWithConfig(text, config); !res.Valid {
+		return ErrTweetTooLong
+	}
+	return nil
+}
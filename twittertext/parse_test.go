@@ -0,0 +1,207 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package twittertext
+
+import (
+	"os"
+	"testing"
+
+	"gopkg.in/tweetlib.v2"
+	"gopkg.in/yaml.v2"
+)
+
+// Reads a test data file in testdata into v
+func loadConformance(t *testing.T, name string, v interface{}) {
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(data, v); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+}
+
+type parseTest struct {
+	Description string
+	Text        string
+	Expected    struct {
+		WeightedLength    int  `yaml:"weightedLength"`
+		Valid             bool `yaml:"valid"`
+		Permillage        int  `yaml:"permillage"`
+		DisplayRangeStart int  `yaml:"displayRangeStart"`
+		DisplayRangeEnd   int  `yaml:"displayRangeEnd"`
+		ValidRangeStart   int  `yaml:"validRangeStart"`
+		ValidRangeEnd     int  `yaml:"validRangeEnd"`
+	}
+}
+
+type weightedTests struct {
+	Tests struct {
+		Tweets []struct {
+			Description string
+			Text        string
+			Expected    bool
+		}
+		Weighted      []parseTest `yaml:"WeightedTweetsCounterTest"`
+		EmojiWeighted []parseTest `yaml:"WeightedTweetsWithDiscountedEmojiCounterTest"`
+	}
+}
+
+type validateTest struct {
+	Description string
+	Text        string
+	Expected    bool
+}
+
+type validateTests struct {
+	Tests struct {
+		Tweets              []validateTest
+		Usernames           []validateTest
+		Lists               []validateTest
+		Hashtags            []validateTest
+		URLs                []validateTest `yaml:"urls"`
+		URLsWithoutProtocol []validateTest `yaml:"urls_without_protocol"`
+		Lengths             []struct {
+			Description string
+			Text        string
+			Expected    int
+		}
+	}
+}
+
+// The rules of twitter-text 1.0, which the conformance file checks: 140
+// code points, URLs counting as 23
+var v1Config = &Config{
+	MaxWeightedTweetLength: 140,
+	Scale:                  1,
+	DefaultWeight:          1,
+	TransformedUrlLength:   23,
+}
+
+func TestConformance(t *testing.T) {
+	var tests validateTests
+	loadConformance(t, "validate.yml", &tests)
+	for _, set := range []struct {
+		name     string
+		tests    []validateTest
+		validate func(string) bool
+	}{
+		{"tweets", tests.Tests.Tweets, func(s string) bool { return ValidateTweetWithConfig(s, v1Config) == nil }},
+		{"usernames", tests.Tests.Usernames, IsValidUsername},
+		{"lists", tests.Tests.Lists, IsValidList},
+		{"hashtags", tests.Tests.Hashtags, IsValidHashtag},
+		{"urls", tests.Tests.URLs, func(s string) bool { return IsValidURL(s, true) }},
+		{"urls_without_protocol", tests.Tests.URLsWithoutProtocol, func(s string) bool { return IsValidURL(s, false) }},
+	} {
+		if len(set.tests) == 0 {
+			t.Errorf("%s: no tests loaded", set.name)
+		}
+		for _, tt := range set.tests {
+			if got := set.validate(tt.Text); got != tt.Expected {
+				t.Errorf("%s: %s: got %v, want %v", set.name, tt.Description, got, tt.Expected)
+			}
+		}
+	}
+
+	if len(tests.Tests.Lengths) == 0 {
+		t.Error("lengths: no tests loaded")
+	}
+	for _, tt := range tests.Tests.Lengths {
+		if got := ParseTweetWithConfig(tt.Text, v1Config).WeightedLength; got != tt.Expected {
+			t.Errorf("lengths: %s: got %d, want %d", tt.Description, got, tt.Expected)
+		}
+	}
+}
+
+func TestValidateTweet(t *testing.T) {
+	var tests weightedTests
+	loadConformance(t, "weighted.yml", &tests)
+	if len(tests.Tests.Tweets) == 0 {
+		t.Fatal("no tests loaded")
+	}
+	for _, tt := range tests.Tests.Tweets {
+		if err := ValidateTweet(tt.Text); (err == nil) != tt.Expected {
+			t.Errorf("%s: ValidateTweet = %v, want valid = %v", tt.Description, err, tt.Expected)
+		}
+	}
+}
+
+func TestParseTweet(t *testing.T) {
+	var tests weightedTests
+	loadConformance(t, "weighted.yml", &tests)
+
+	// the rules before emoji counted as a single code point
+	v2 := *DefaultConfig
+	v2.EmojiParsingEnabled = false
+
+	for _, set := range []struct {
+		name   string
+		config *Config
+		tests  []parseTest
+	}{
+		{"WeightedTweetsCounterTest", &v2, tests.Tests.Weighted},
+		{"WeightedTweetsWithDiscountedEmojiCounterTest", DefaultConfig, tests.Tests.EmojiWeighted},
+	} {
+		if len(set.tests) == 0 {
+			t.Errorf("%s: no tests loaded", set.name)
+		}
+		for _, tt := range set.tests {
+			res := ParseTweetWithConfig(tt.Text, set.config)
+			want := ParseResults{
+				WeightedLength:    tt.Expected.WeightedLength,
+				Permillage:        tt.Expected.Permillage,
+				Valid:             tt.Expected.Valid,
+				DisplayRangeStart: tt.Expected.DisplayRangeStart,
+				DisplayRangeEnd:   tt.Expected.DisplayRangeEnd,
+				ValidRangeStart:   tt.Expected.ValidRangeStart,
+				ValidRangeEnd:     tt.Expected.ValidRangeEnd,
+			}
+			if res != want {
+				t.Errorf("%s: %s:\ngot  %+v\nwant %+v", set.name, tt.Description, res, want)
+			}
+		}
+	}
+}
+
+func TestConfigFromAPI(t *testing.T) {
+	tests := []struct {
+		conf tweetlib.Configuration
+		want int
+	}{
+		{tweetlib.Configuration{ShortUrlLength: 23, ShortUrlLengthHttps: 23}, 23},
+		{tweetlib.Configuration{ShortUrlLength: 22, ShortUrlLengthHttps: 23}, 23},
+		{tweetlib.Configuration{ShortUrlLength: 20, ShortUrlLengthHttps: 21}, 21},
+		{tweetlib.Configuration{ShortUrlLength: 25, ShortUrlLengthHttps: 24}, 25},
+		{tweetlib.Configuration{}, DefaultConfig.TransformedUrlLength},
+	}
+	for _, tt := range tests {
+		conf := tt.conf
+		if got := ConfigFromAPI(&conf).TransformedUrlLength; got != tt.want {
+			t.Errorf("ConfigFromAPI(%d, %d) = %d, want %d", tt.conf.ShortUrlLength, tt.conf.ShortUrlLengthHttps, got, tt.want)
+		}
+	}
+	if DefaultConfig.TransformedUrlLength != 23 {
+		t.Errorf("ConfigFromAPI changed DefaultConfig")
+	}
+}
+
+func TestValidateTweetErrors(t *testing.T) {
+	tests := []struct {
+		text string
+		want error
+	}{
+		{"", ErrEmptyTweet},
+		{"abc\uFFFE", ErrInvalidCharacters},
+		{string(make([]rune, 281)), ErrTweetTooLong},
+		{"I am a Tweet", nil},
+	}
+	for _, tt := range tests {
+		if err := ValidateTweet(tt.text); err != tt.want {
+			t.Errorf("ValidateTweet(%.20q) = %v, want %v", tt.text, err, tt.want)
+		}
+	}
+}
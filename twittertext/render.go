@@ -0,0 +1,209 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package twittertext
+
+import (
+	"html"
+	"net/url"
+	"sort"
+	"strings"
+
+	"gopkg.in/tweetlib.v2"
+)
+
+// How each part of a tweet is written by a renderer. seg is the part of
+// the text the entity covers, as written in the tweet.
+type renderer struct {
+	text    func(s string) string
+	hashtag func(seg string, e tweetlib.HashtagEntity) string
+	cashtag func(seg string, e tweetlib.SymbolEntity) string
+	mention func(seg string, e tweetlib.MentionEntity) string
+	url     func(seg string, e tweetlib.URLEntity) string
+	// Media are written after the text, separated by sep
+	media func(m tweetlib.MediaEntity) string
+	sep   string
+}
+
+// Renders the text of a tweet to HTML. Mentions, hashtags and cashtags link
+// to twitter.com, URLs link to their expanded form and show their display
+// form, and attached media are appended as linked images. Only the display
+// range of the text is rendered, so the leading @mentions of replies are
+// left out. To render a retweet in full, render Tweet.Original.
+func RenderHTML(t *tweetlib.Tweet) string {
+	return render(t, htmlRenderer)
+}
+
+// Renders the text of a tweet to Markdown. See RenderHTML.
+func RenderMarkdown(t *tweetlib.Tweet) string {
+	return render(t, markdownRenderer)
+}
+
+// Renders the text of a tweet to plain text, with t.co links replaced by
+// the URLs they point to and the URL of every attached media appended.
+// See RenderHTML.
+func RenderText(t *tweetlib.Tweet) string {
+	return render(t, textRenderer)
+}
+
+var htmlRenderer = &renderer{
+	text: html.EscapeString,
+	hashtag: func(seg string, e tweetlib.HashtagEntity) string {
+		return htmlLink("https://twitter.com/hashtag/"+url.PathEscape(e.Text), seg)
+	},
+	cashtag: func(seg string, e tweetlib.SymbolEntity) string {
+		return htmlLink("https://twitter.com/search?q="+url.QueryEscape("$"+e.Text), seg)
+	},
+	mention: func(seg string, e tweetlib.MentionEntity) string {
+		return htmlLink("https://twitter.com/"+e.ScreenName, seg)
+	},
+	url: func(seg string, e tweetlib.URLEntity) string {
+		return htmlLink(expandedURL(e), displayURL(e))
+	},
+	media: func(m tweetlib.MediaEntity) string {
+		img := `<img src="` + html.EscapeString(m.MediaUrlHttps) + `" alt="` + html.EscapeString(m.ExtAltText) + `">`
+		return `<a href="` + html.EscapeString(m.ExpandedUrl) + `">` + img + `</a>`
+	},
+	sep: "<br>",
+}
+
+func htmlLink(href, text string) string {
+	return `<a href="` + html.EscapeString(href) + `">` + html.EscapeString(text) + `</a>`
+}
+
+// Characters that have a meaning in Markdown text
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `\<`, `>`, `\>`,
+)
+
+var markdownRenderer = &renderer{
+	text: markdownEscaper.Replace,
+	hashtag: func(seg string, e tweetlib.HashtagEntity) string {
+		return markdownLink("https://twitter.com/hashtag/"+url.PathEscape(e.Text), seg)
+	},
+	cashtag: func(seg string, e tweetlib.SymbolEntity) string {
+		return markdownLink("https://twitter.com/search?q="+url.QueryEscape("$"+e.Text), seg)
+	},
+	mention: func(seg string, e tweetlib.MentionEntity) string {
+		return markdownLink("https://twitter.com/"+e.ScreenName, seg)
+	},
+	url: func(seg string, e tweetlib.URLEntity) string {
+		return markdownLink(expandedURL(e), displayURL(e))
+	},
+	media: func(m tweetlib.MediaEntity) string {
+		img := "![" + markdownEscaper.Replace(m.ExtAltText) + "](" + markdownURL(m.MediaUrlHttps) + ")"
+		return "[" + img + "](" + markdownURL(m.ExpandedUrl) + ")"
+	},
+	sep: "\n\n",
+}
+
+func markdownLink(href, text string) string {
+	return "[" + markdownEscaper.Replace(text) + "](" + markdownURL(href) + ")"
+}
+
+// Escapes the characters that would end the destination of a link
+func markdownURL(u string) string {
+	return strings.NewReplacer("(", "%28", ")", "%29", " ", "%20").Replace(u)
+}
+
+var textRenderer = &renderer{
+	text:    func(s string) string { return s },
+	hashtag: func(seg string, e tweetlib.HashtagEntity) string { return seg },
+	cashtag: func(seg string, e tweetlib.SymbolEntity) string { return seg },
+	mention: func(seg string, e tweetlib.MentionEntity) string { return seg },
+	url:     func(seg string, e tweetlib.URLEntity) string { return expandedURL(e) },
+	media:   func(m tweetlib.MediaEntity) string { return m.ExpandedUrl },
+	sep:     " ",
+}
+
+func expandedURL(e tweetlib.URLEntity) string {
+	if e.ExpandedUrl != "" {
+		return e.ExpandedUrl
+	}
+	return e.Url
+}
+
+func displayURL(e tweetlib.URLEntity) string {
+	if e.DisplayUrl != "" {
+		return e.DisplayUrl
+	}
+	return expandedURL(e)
+}
+
+func render(t *tweetlib.Tweet, r *renderer) string {
+	et := t.Extended()
+	text := newText(t.DisplayText())
+	// indices refer to the full text, which the display text starts at
+	// the display range of
+	shift := 0
+	if et.DisplayTextRange.End() != 0 {
+		shift = et.DisplayTextRange.Start()
+	}
+
+	// entities, keyed by their UTF-16 indices
+	type entity struct {
+		indices tweetlib.Indices
+		write   func(seg string) string
+	}
+	var ents []entity
+	for _, e := range et.Entities.Hashtags {
+		e := e
+		ents = append(ents, entity{e.Indices, func(seg string) string { return r.hashtag(seg, e) }})
+	}
+	for _, e := range et.Entities.Symbols {
+		e := e
+		ents = append(ents, entity{e.Indices, func(seg string) string { return r.cashtag(seg, e) }})
+	}
+	for _, e := range et.Entities.UserMentions {
+		e := e
+		ents = append(ents, entity{e.Indices, func(seg string) string { return r.mention(seg, e) }})
+	}
+	for _, e := range et.Entities.Urls {
+		e := e
+		ents = append(ents, entity{e.Indices, func(seg string) string { return r.url(seg, e) }})
+	}
+	// the link to attached media is removed from the text, the media
+	// being written after it
+	media := t.Media()
+	if len(media) > 0 {
+		ents = append(ents, entity{media[0].Indices, func(string) string { return "" }})
+	}
+	sort.SliceStable(ents, func(i, j int) bool { return ents[i].indices.Start() < ents[j].indices.Start() })
+
+	var b strings.Builder
+	pos := 0
+	for _, e := range ents {
+		start, end := e.indices.Start()-shift, e.indices.End()-shift
+		if start < text.offsets[pos] || end > text.len16() || start >= end {
+			// out of the display range, overlapping or invalid
+			continue
+		}
+		i, j := runeAt(text, start), runeAt(text, end)
+		b.WriteString(r.text(text.slice(pos, i)))
+		b.WriteString(e.write(text.slice(i, j)))
+		pos = j
+	}
+	b.WriteString(r.text(text.slice(pos, len(text.runes))))
+
+	out := b.String()
+	if len(media) > 0 {
+		out = strings.TrimRight(out, " ")
+	}
+	for _, m := range media {
+		if out != "" {
+			out += r.sep
+		}
+		out += r.media(m)
+	}
+	return out
+}
+
+// Returns the index of the code point at UTF-16 offset n, or of the next
+// one if n falls within a surrogate pair
+func runeAt(t *utext, n int) int {
+	return sort.SearchInts(t.offsets, n)
+}
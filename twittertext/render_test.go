@@ -0,0 +1,149 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package twittertext
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/tweetlib.v2"
+)
+
+var renderTests = []struct {
+	name     string
+	tweet    string
+	html     string
+	markdown string
+	text     string
+}{
+	{
+		"emoji before entities",
+		`{
+			"full_text": "\ud83d\udc31 #cats by @golang",
+			"display_text_range": [0, 19],
+			"entities": {
+				"hashtags": [{"text": "cats", "indices": [3, 8]}],
+				"user_mentions": [{"screen_name": "golang", "indices": [12, 19]}]
+			}
+		}`,
+		`🐱 <a href="https://twitter.com/hashtag/cats">#cats</a> by <a href="https://twitter.com/golang">@golang</a>`,
+		`🐱 [#cats](https://twitter.com/hashtag/cats) by [@golang](https://twitter.com/golang)`,
+		`🐱 #cats by @golang`,
+	},
+	{
+		"escaped text before entities",
+		`{
+			"full_text": "Q&amp;A: 1 &lt; 2 &gt; 0 $TWTR https://t.co/abc123",
+			"display_text_range": [0, 40],
+			"entities": {
+				"symbols": [{"text": "TWTR", "indices": [15, 20]}],
+				"urls": [{"url": "https://t.co/abc123", "expanded_url": "https://go.dev/doc/faq?a=1&b=2",
+					"display_url": "go.dev/doc/faq?a=1&b…", "indices": [21, 40]}]
+			}
+		}`,
+		`Q&amp;A: 1 &lt; 2 &gt; 0 <a href="https://twitter.com/search?q=%24TWTR">$TWTR</a> <a href="https://go.dev/doc/faq?a=1&amp;b=2">go.dev/doc/faq?a=1&amp;b…</a>`,
+		`Q&A: 1 \< 2 \> 0 [$TWTR](https://twitter.com/search?q=%24TWTR) [go.dev/doc/faq?a=1&b…](https://go.dev/doc/faq?a=1&b=2)`,
+		`Q&A: 1 < 2 > 0 $TWTR https://go.dev/doc/faq?a=1&b=2`,
+	},
+	{
+		"reply mention, emoji and escaped text before the display range start",
+		`{
+			"full_text": "@gopher \ud83d\udc31&amp;\ud83d\udc36 #pets https://t.co/xyz123",
+			"display_text_range": [8, 39],
+			"entities": {
+				"hashtags": [{"text": "pets", "indices": [14, 19]}],
+				"user_mentions": [{"screen_name": "gopher", "indices": [0, 7]}],
+				"urls": [{"url": "https://t.co/xyz123", "expanded_url": "https://example.com/pets",
+					"display_url": "example.com/pets", "indices": [20, 39]}]
+			}
+		}`,
+		`🐱&amp;🐶 <a href="https://twitter.com/hashtag/pets">#pets</a> <a href="https://example.com/pets">example.com/pets</a>`,
+		`🐱&🐶 [#pets](https://twitter.com/hashtag/pets) [example.com/pets](https://example.com/pets)`,
+		`🐱&🐶 #pets https://example.com/pets`,
+	},
+	{
+		"reply with media",
+		`{
+			"full_text": "@gopher @rob Look &amp; see https://t.co/media1",
+			"display_text_range": [13, 23],
+			"entities": {
+				"user_mentions": [
+					{"screen_name": "gopher", "indices": [0, 7]},
+					{"screen_name": "rob", "indices": [8, 12]}
+				],
+				"media": [{"url": "https://t.co/media1", "indices": [24, 43],
+					"media_url_https": "https://pbs.twimg.com/media/a.jpg",
+					"expanded_url": "https://twitter.com/gopher/status/1/photo/1"}]
+			},
+			"extended_entities": {
+				"media": [
+					{"url": "https://t.co/media1", "indices": [24, 43], "ext_alt_text": "A gopher",
+						"media_url_https": "https://pbs.twimg.com/media/a.jpg",
+						"expanded_url": "https://twitter.com/gopher/status/1/photo/1"},
+					{"url": "https://t.co/media1", "indices": [24, 43],
+						"media_url_https": "https://pbs.twimg.com/media/b.jpg",
+						"expanded_url": "https://twitter.com/gopher/status/1/photo/2"}
+				]
+			}
+		}`,
+		`Look &amp; see<br>` +
+			`<a href="https://twitter.com/gopher/status/1/photo/1"><img src="https://pbs.twimg.com/media/a.jpg" alt="A gopher"></a><br>` +
+			`<a href="https://twitter.com/gopher/status/1/photo/2"><img src="https://pbs.twimg.com/media/b.jpg" alt=""></a>`,
+		"Look & see\n\n" +
+			"[![A gopher](https://pbs.twimg.com/media/a.jpg)](https://twitter.com/gopher/status/1/photo/1)\n\n" +
+			"[![](https://pbs.twimg.com/media/b.jpg)](https://twitter.com/gopher/status/1/photo/2)",
+		`Look & see https://twitter.com/gopher/status/1/photo/1 https://twitter.com/gopher/status/1/photo/2`,
+	},
+	{
+		"media link without a display range",
+		`{
+			"text": "Look https://t.co/media1",
+			"entities": {
+				"media": [{"url": "https://t.co/media1", "indices": [5, 24],
+					"media_url_https": "https://pbs.twimg.com/media/a.jpg",
+					"expanded_url": "https://twitter.com/gopher/status/1/photo/1"}]
+			}
+		}`,
+		`Look<br><a href="https://twitter.com/gopher/status/1/photo/1"><img src="https://pbs.twimg.com/media/a.jpg" alt=""></a>`,
+		"Look\n\n[![](https://pbs.twimg.com/media/a.jpg)](https://twitter.com/gopher/status/1/photo/1)",
+		`Look https://twitter.com/gopher/status/1/photo/1`,
+	},
+	{
+		"t.co link without an expanded URL",
+		`{
+			"text": "see https://t.co/abc123 *now*",
+			"entities": {
+				"urls": [{"url": "https://t.co/abc123", "indices": [4, 23]}]
+			}
+		}`,
+		`see <a href="https://t.co/abc123">https://t.co/abc123</a> *now*`,
+		`see [https://t.co/abc123](https://t.co/abc123) \*now\*`,
+		`see https://t.co/abc123 *now*`,
+	},
+}
+
+func TestRender(t *testing.T) {
+	for _, tt := range renderTests {
+		var tweet tweetlib.Tweet
+		if err := json.Unmarshal([]byte(tt.tweet), &tweet); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		for _, f := range []struct {
+			format string
+			render func(*tweetlib.Tweet) string
+			want   string
+		}{
+			{"HTML", RenderHTML, tt.html},
+			{"Markdown", RenderMarkdown, tt.markdown},
+			{"text", RenderText, tt.text},
+		} {
+			if got := f.render(&tweet); got != f.want {
+				t.Errorf("%s: %s:\ngot  %s\nwant %s", tt.name, f.format, got, f.want)
+			}
+		}
+	}
+}
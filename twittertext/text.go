@@ -0,0 +1,48 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package twittertext
+
+import "unicode/utf16"
+
+// A text split into code points along with the offset of each of them in
+// UTF-16 code units, which is how Twitter expresses indices.
+type utext struct {
+	runes []rune
+	// offsets[i] is the offset of runes[i]; the last element is the length
+	// of the whole text
+	offsets []int
+}
+
+func newText(s string) *utext {
+	t := &utext{runes: []rune(s)}
+	t.offsets = make([]int, len(t.runes)+1)
+	n := 0
+	for i, r := range t.runes {
+		t.offsets[i] = n
+		n += utf16.RuneLen(r)
+	}
+	t.offsets[len(t.runes)] = n
+	return t
+}
+
+// Returns the code point at i, or -1 if i is out of range
+func (t *utext) at(i int) rune {
+	if i < 0 || i >= len(t.runes) {
+		return -1
+	}
+	return t.runes[i]
+}
+
+// Returns the code points between i and j as a string
+func (t *utext) slice(i, j int) string {
+	return string(t.runes[i:j])
+}
+
+// Length of the text in UTF-16 code units
+func (t *utext) len16() int {
+	return t.offsets[len(t.runes)]
+}
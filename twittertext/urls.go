@@ -0,0 +1,289 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package twittertext
+
+import (
+	"strings"
+	"unicode"
+)
+
+// A URL found in a text. start and end are code point indices.
+type urlMatch struct {
+	start, end  int
+	hasProtocol bool
+}
+
+// Country code TLDs that are linked even without a protocol, a subdomain
+// or a path, as in "t.co"
+var specialCountryTLD = map[string]bool{"co": true, "tv": true}
+
+// The TLD lists are generated from the conformance tests
+//go:generate go run gen_tlds.go
+
+var (
+	countryTLD = tldSet(countryTLDs)
+	genericTLD = tldSet(genericTLDs)
+)
+
+func tldSet(list string) map[string]bool {
+	set := make(map[string]bool)
+	for _, tld := range strings.Fields(list) {
+		set[tld] = true
+	}
+	return set
+}
+
+// Finds the URLs in t following the twitter-text rules. Unless
+// withoutProtocol is set, only URLs starting with http:// or https:// are
+// extracted.
+func extractURLs(t *utext, withoutProtocol bool) (urls []urlMatch) {
+	for i := 0; i < len(t.runes); i++ {
+		if i > 0 && !isValidURLPreceding(t.runes[i-1]) {
+			continue
+		}
+		proto := protocolLen(t, i)
+		if proto == 0 {
+			if !withoutProtocol || !isBareDomainChar(t.runes[i]) || isBareDomainChar(t.at(i-1)) {
+				continue
+			}
+			switch t.at(i - 1) {
+			case '-', '_', '.', '/':
+				continue
+			}
+		}
+		if m, ok := matchURL(t, i, proto); ok {
+			urls = append(urls, m)
+			i = m.end - 1
+		}
+	}
+	return
+}
+
+// Length of the http:// or https:// protocol at i, or 0 if there is none
+func protocolLen(t *utext, i int) int {
+	for _, p := range []string{"https://", "http://"} {
+		n := len(p)
+		if i+n <= len(t.runes) && strings.EqualFold(t.slice(i, i+n), p) {
+			return n
+		}
+	}
+	return 0
+}
+
+// Matches a URL at i, whose protocol is proto code points long
+func matchURL(t *utext, start, proto int) (m urlMatch, ok bool) {
+	labelChar := isDomainChar
+	if proto == 0 {
+		labelChar = isBareDomainChar
+	}
+	// split the domain into labels
+	type label struct{ start, end int }
+	var labels []label
+	j := start + proto
+	for {
+		k := j
+		for labelChar(t.at(k)) {
+			k++
+		}
+		if k == j {
+			break
+		}
+		labels = append(labels, label{j, k})
+		if t.at(k) != '.' || !labelChar(t.at(k+1)) {
+			break
+		}
+		j = k + 1
+	}
+	// the domain ends with the last valid TLD that is not directly followed
+	// by a letter, a digit, "@" or "+". The TLD may be followed by a dash
+	// or an underscore, as in the typo "example.com-and-more".
+	n := len(labels)
+	tldEnd := -1
+	for ; n >= 2 && tldEnd < 0; n-- {
+		l := labels[n-1]
+		for e := l.end; e > l.start; e-- {
+			if e < l.end && t.runes[e] != '-' && t.runes[e] != '_' {
+				continue
+			}
+			if c := t.at(e); c < 0x80 && (isASCIIAlnum(c) || c == '@' || c == '+') {
+				continue
+			}
+			if isTLD(strings.ToLower(t.slice(l.start, e))) {
+				tldEnd = e
+				break
+			}
+		}
+	}
+	if tldEnd < 0 {
+		return m, false
+	}
+	n++
+	labels[n-1].end = tldEnd
+	for k, l := range labels[:n] {
+		// labels cannot start or end with a dash or an underscore, and
+		// only subdomains may contain underscores
+		s := t.slice(l.start, l.end)
+		if strings.Trim(s, "-_") != s || k >= n-2 && strings.ContainsRune(s, '_') {
+			return m, false
+		}
+	}
+	tld := strings.ToLower(t.slice(labels[n-1].start, tldEnd))
+	end := tldEnd
+
+	// port
+	if t.at(end) == ':' && isASCIIDigit(t.at(end+1)) {
+		end++
+		for isASCIIDigit(t.at(end)) {
+			end++
+		}
+	}
+	// path
+	hasPath := false
+	if t.at(end) == '/' {
+		end = matchPath(t, end)
+		hasPath = true
+	}
+	// query
+	if t.at(end) == '?' {
+		last := end
+		for k := end + 1; isQueryChar(t.at(k)); k++ {
+			if isQueryEndingChar(t.at(k)) {
+				last = k + 1
+			}
+		}
+		if last > end {
+			end = last
+		}
+	}
+
+	if proto == 0 {
+		// a bare domain with a country code TLD, like "example.jp", is
+		// not a URL
+		if !hasPath && n == 2 && countryTLD[tld] && !specialCountryTLD[tld] {
+			return m, false
+		}
+	} else if strings.EqualFold(t.slice(start+proto, labels[n-1].end), "t.co") && hasPath {
+		// t.co links never have more than a single path segment
+		k := labels[n-1].end + 1
+		for isASCIIAlnum(t.at(k)) {
+			k++
+		}
+		if k < end && t.at(k) != '?' {
+			end = k
+		}
+	}
+	return urlMatch{start, end, proto > 0}, true
+}
+
+func isTLD(s string) bool {
+	return genericTLD[s] || countryTLD[s] || len(s) > 4 && strings.HasPrefix(s, "xn--")
+}
+
+// Matches the path starting with the slash at i and returns where it ends.
+// The path may contain balanced parentheses but cannot end with most
+// punctuation.
+func matchPath(t *utext, i int) int {
+	last := i + 1 // the slash alone is a valid path
+	depth := 0
+	for k := i + 1; ; k++ {
+		c := t.at(k)
+		switch {
+		case c == '(' && depth < 2:
+			depth++
+			continue
+		case c == ')' && depth > 0:
+			depth--
+			if depth == 0 {
+				last = k + 1
+			}
+			continue
+		case !isPathChar(c):
+			return last
+		}
+		if depth == 0 && isPathEndingChar(c) {
+			last = k + 1
+		}
+	}
+}
+
+// Reports whether a URL may start right after c
+func isValidURLPreceding(c rune) bool {
+	switch {
+	case isASCIIAlnum(c), c == '@', c == '＠', c == '$', c == '#', c == '＃':
+		return false
+	case isInvalidChar(c):
+		return false
+	}
+	return true
+}
+
+func isASCIIDigit(c rune) bool { return c >= '0' && c <= '9' }
+
+func isASCIIAlnum(c rune) bool {
+	return isASCIIDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// Domains of URLs with a protocol may contain any character that is not
+// ASCII punctuation, a space or a control character
+func isDomainChar(c rune) bool {
+	switch {
+	case c == '-' || c == '_':
+		return true
+	case c < 0, c < 0x80 && !isASCIIAlnum(c):
+		return false
+	case isDirectionalMarker(c), isInvalidChar(c):
+		return false
+	}
+	return !unicode.IsSpace(c) && !unicode.IsControl(c)
+}
+
+// Marks controlling the direction of text, which may precede URLs but are
+// never part of them
+func isDirectionalMarker(c rune) bool {
+	return c == 0x061C || c == 0x200E || c == 0x200F || c >= 0x202A && c <= 0x202E || c >= 0x2066 && c <= 0x2069
+}
+
+// Domains of URLs without a protocol are restricted to ASCII and accented
+// Latin letters
+func isBareDomainChar(c rune) bool {
+	return c == '-' || c == '_' || isASCIIAlnum(c) || isLatinAccent(c)
+}
+
+// Latin letters with accents and Cyrillic letters are allowed in paths
+func isPathLetter(c rune) bool {
+	return isASCIIAlnum(c) || isLatinAccent(c) || (c >= 0x0400 && c <= 0x04FF)
+}
+
+func isLatinAccent(c rune) bool {
+	switch {
+	case c >= 0x00C0 && c <= 0x024F && c != 0x00D7 && c != 0x00F7:
+		return true
+	case c >= 0x0253 && c <= 0x0254, c >= 0x0256 && c <= 0x0257, c == 0x0259,
+		c == 0x025B, c == 0x0263, c == 0x0268, c == 0x026F, c == 0x0272,
+		c == 0x0289, c == 0x028B, c == 0x02BB:
+		return true
+	case c >= 0x0300 && c <= 0x036F, c >= 0x1E00 && c <= 0x1EFF:
+		return true
+	}
+	return false
+}
+
+func isPathChar(c rune) bool {
+	return isPathLetter(c) || strings.ContainsRune("!*';:=+,.$/%#[]-_~&|@–", c)
+}
+
+func isPathEndingChar(c rune) bool {
+	return isPathLetter(c) || strings.ContainsRune("+-=_#/", c)
+}
+
+func isQueryChar(c rune) bool {
+	return isASCIIAlnum(c) || strings.ContainsRune("!?*'();:&=+$/%#[]-_.,~|@", c)
+}
+
+func isQueryEndingChar(c rune) bool {
+	return isASCIIAlnum(c) || strings.ContainsRune("-_&=#/", c)
+}
@@ -0,0 +1,35 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package twittertext
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTLDs(t *testing.T) {
+	var tests struct {
+		Tests struct {
+			Country []extractTest
+			Generic []extractTest
+		}
+	}
+	loadConformance(t, "tlds.yml", &tests)
+	all := append(tests.Tests.Country, tests.Tests.Generic...)
+	if len(all) == 0 {
+		t.Fatal("no tests loaded")
+	}
+	for _, tt := range all {
+		var got []string
+		for _, u := range ExtractURLs(tt.Text) {
+			got = append(got, u.Url)
+		}
+		if !slices.Equal(got, tt.Expected) {
+			t.Errorf("%s: ExtractURLs(%q) = %q, want %q", tt.Description, tt.Text, got, tt.Expected)
+		}
+	}
+}
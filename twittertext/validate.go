@@ -0,0 +1,83 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package twittertext
+
+import (
+	"net"
+	"regexp"
+	"strings"
+)
+
+// Returns whether text is a single @mention, e.g. "@golang"
+func IsValidUsername(text string) bool {
+	t := newText(text)
+	return isWhole(t, extractMentions(t))
+}
+
+// Returns whether text is a single reference to a list, e.g.
+// "@golang/team"
+func IsValidList(text string) bool {
+	t := newText(text)
+	m, slug, ok := matchMentionOrList(t, 0)
+	return ok && slug != "" && m.start == 0 && m.end == len(t.runes)
+}
+
+// Returns whether text is a single hashtag, e.g. "#golang"
+func IsValidHashtag(text string) bool {
+	t := newText(text)
+	return isWhole(t, extractHashtags(t))
+}
+
+// Returns whether matches is a single entity spanning the whole of t
+func isWhole(t *utext, matches []match) bool {
+	return len(matches) == 1 && matches[0].start == 0 && matches[0].end == len(t.runes)
+}
+
+// Parts of a URL, as in RFC 3986 with the characters twitter-text accepts
+const (
+	urlPChar    = `(?:[a-z\x{0400}-\x{04FF}0-9\-._~]|%[0-9a-f]{2}|[!$&'()*+,;=]|[:|@])`
+	urlUserinfo = `(?:[a-z\x{0400}-\x{04FF}0-9\-._~]|%[0-9a-f]{2}|[!$&'()*+,;=]|:)*`
+	urlOctet    = `(?:[0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])`
+	urlIPv4     = `(?:` + urlOctet + `\.){3}` + urlOctet
+	// Labels may hold any non-ASCII character. Only subdomains may hold
+	// underscores, and the TLD starts with a letter.
+	urlDomain = `(?:(?:(?:[a-z0-9]|[^\x00-\x7f])(?:(?:[a-z0-9_\-]|[^\x00-\x7f])*(?:[a-z0-9]|[^\x00-\x7f]))?\.)*` +
+		`(?:(?:[a-z0-9]|[^\x00-\x7f])(?:(?:[a-z0-9\-]|[^\x00-\x7f])*(?:[a-z0-9]|[^\x00-\x7f]))?\.)` +
+		`(?:(?:[a-z]|[^\x00-\x7f])(?:(?:[a-z0-9\-]|[^\x00-\x7f])*(?:[a-z0-9]|[^\x00-\x7f]))?))`
+)
+
+var (
+	urlParts      = regexp.MustCompile(`^(?:([^:/?#]+)://)?([^/?#]*)([^?#]*)(?:\?([^#]*))?(?:#(.*))?$`)
+	urlHTTPScheme = regexp.MustCompile(`(?i)^https?$`)
+	urlAuthority  = regexp.MustCompile(`(?i)^(?:` + urlUserinfo + `@)?(` + urlIPv4 + `|\[[0-9a-f:.]+\]|` + urlDomain + `)(?::[0-9]{1,5})?$`)
+	urlPath       = regexp.MustCompile(`(?i)^(?:/` + urlPChar + `*)*$`)
+	urlQuery      = regexp.MustCompile(`(?i)^(?:` + urlPChar + `|/|\?)*$`)
+)
+
+// Returns whether text is a single valid URL. Domains may hold non-ASCII
+// characters. If requireProtocol is set, the URL must start with http://
+// or https://.
+func IsValidURL(text string, requireProtocol bool) bool {
+	parts := urlParts.FindStringSubmatch(text)
+	if parts == nil {
+		return false
+	}
+	scheme, authority, path, query, fragment := parts[1], parts[2], parts[3], parts[4], parts[5]
+	if requireProtocol && !urlHTTPScheme.MatchString(scheme) {
+		return false
+	}
+	host := urlAuthority.FindStringSubmatch(authority)
+	if host == nil {
+		return false
+	}
+	if strings.HasPrefix(host[1], "[") {
+		if net.ParseIP(strings.Trim(host[1], "[]")) == nil {
+			return false
+		}
+	}
+	return urlPath.MatchString(path) && urlQuery.MatchString(query) && urlQuery.MatchString(fragment)
+}
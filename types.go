@@ -5,11 +5,6 @@
 // license that can be found in the LICENSE file.
 package tweetlib
 
-import (
-	"fmt"
-	"net/url"
-)
-
 type Configuration struct {
 	CharactersReservedPerMedia int      `json:"characters_reserved_per_media"`
 	MaxMediaPerUpload          int      `json:"max_media_per_upload"`
@@ -24,19 +19,6 @@ type Configuration struct {
 	ShortUrlLength      int `json:"short_url_length"`
 }
 
-type LimitStatus struct {
-	RemainingHits      int    `json:"remaining_hits"`
-	ResetTimeInSeconds int    `json:"reset_time_in_secods"`
-	HourlyLimit        int    `json:"hourly_limit"`
-	ResetTime          string `json:"reset_time"`
-	Photos             struct {
-		RemainingHits      int    `json:"remaining_hits"`
-		ResetTimeInSeconds int    `json:"reset_time_in_secods"`
-		ResetTime          string `json:"reset_time"`
-		DailyLimit         int    `json:"daily_limit"`
-	} `json:"photos"`
-}
-
 type List struct {
 	User            *User  `json:"user"`
 	Name            string `json:"name"`
@@ -44,18 +26,33 @@ type List struct {
 	Mode            string `json:"mode"`
 	IdStr           string `json:"id_str"`
 	Uri             string `json:"uri"`
-	Id              int    `json:"id"`
+	Id              ID     `json:"id"`
 	MemberCount     int    `json:"member_count"`
 	Following       bool   `json:"following"`
 	FullName        string `json:"full_name"`
 	SubscriberCount int    `json:"subscriber_count"`
 	Description     string `json:"description"`
+	CreatedAt       Time   `json:"created_at"`
+
+	RawJSON
+}
+
+func (l *List) UnmarshalJSON(data []byte) error {
+	type list List
+	return l.decodeJSON(data, (*list)(l))
+}
+
+func (l List) MarshalJSON() ([]byte, error) {
+	type list List
+	return l.encodeJSON((*list)(&l))
 }
 
 type ListList []List
 
+// A location Twitter has trending topics for
 type TrendLocation struct {
 	Woeid       int64  `json:"woeid"`
+	ParentId    int64  `json:"parentid"`
 	Name        string `json:"name"`
 	CountryCode string `json:"countryCode"`
 	Country     string `json:"country"`
@@ -66,28 +63,5 @@ type TrendLocation struct {
 	} `json:"placeType"`
 }
 
+// A list of trend locations
 type TrendLocationList []TrendLocation
-
-// Optionals: used to provide optional arguments to
-// API calls
-//
-// Usage:
-//
-//   opts := NewOptionals()
-//   opts.Add("count", 10)
-//   opts.Add("lat", -37.102013)
-//   opts.Add("user_id", "twitteruser")
-type Optionals struct {
-	Values url.Values
-}
-
-// NewOptionals returns a new instance of Optionals
-func NewOptionals() *Optionals {
-	return &Optionals{make(url.Values)}
-}
-
-// Add: adds a new optional parameter to be used in
-// an API request. The value needs to be "stringified"
-func (o *Optionals) Add(name string, value interface{}) {
-	o.Values.Add(name, fmt.Sprintf("%v", value))
-}
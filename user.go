@@ -8,7 +8,6 @@ package tweetlib
 
 import (
 	"errors"
-	"strconv"
 	"strings"
 )
 
@@ -21,55 +20,228 @@ type UserService struct {
 // call used, this struct may not be fully filled.
 // Some calls use only the Id field.
 type User struct {
-	ScreenName                string `json:"screen_name"`
-	ListedCount               int64  `json:"listed_count"`
-	FollowersCount            int64  `json:"followers_count"`
-	Location                  string `json:"location"`
-	ProfileBackgroundImageUrl string `json:"profile_background_image_url"`
-	Name                      string `json:"name"`
-	Notifications             bool   `json:"notifications"`
-	Protected                 bool   `json:"protected"`
-	IdStr                     string `json:"id_str"`
-	ProfileBackgroundColor    string `json:"profile_background_color"`
-	CreatedAt                 string `json:"created_at"`
-	Url                       string `json:"url"`
-	TimeZone                  string `json:"time_zone"`
-	Id                        int64  `json:"id"`
-	Verified                  bool   `json:"verified"`
-	ProfileLinkColor          string `json:"profile_link_color"`
-	ProfileImageUrl           string `json:"profile_image_url"`
-	Status                    *Tweet `json:"status"`
-	ProfileUseBackgroundImage bool   `json:"profile_use_background_image"`
-	FavouritesCount           int64  `json:"favourites_count"`
-	ProfileSidebarFillColor   string `json:"profile_sidebar_fill_color"`
-	UtcOffset                 int64  `json:"utc_offset"`
-	IsTranslator              bool   `json:"is_translator"`
-	FollowRequestSent         bool   `json:"follow_request_sent"`
-	Following                 bool   `json:"following"`
-	ProfileBackgroundTile     bool   `json:"profile_background_tile"`
-	ShowAllInlineMedia        bool   `json:"show_all_inline_media"`
-	ProfileTextColor          string `json:"profile_text_color"`
-	Lang                      string `json:"lang"`
-	StatusesCount             int64  `json:"statuses_count"`
-	ContributorsEnabled       bool   `json:"contributors_enabled"`
-	FriendsCount              int64  `json:"friends_count"`
-	GeoEnabled                bool   `json:"geo_enabled"`
-	Description               string `json:"description"`
-	ProfileSidebarBorderColor string `json:"profile_sidebar_border_color"`
+	ScreenName                     string       `json:"screen_name"`
+	ListedCount                    int64        `json:"listed_count"`
+	FollowersCount                 int64        `json:"followers_count"`
+	Location                       string       `json:"location"`
+	ProfileBackgroundImageUrl      string       `json:"profile_background_image_url"`
+	Name                           string       `json:"name"`
+	Notifications                  bool         `json:"notifications"`
+	Protected                      bool         `json:"protected"`
+	IdStr                          string       `json:"id_str"`
+	ProfileBackgroundColor         string       `json:"profile_background_color"`
+	CreatedAt                      Time         `json:"created_at"`
+	Url                            string       `json:"url"`
+	TimeZone                       string       `json:"time_zone"`
+	Id                             ID           `json:"id"`
+	Verified                       bool         `json:"verified"`
+	ProfileLinkColor               string       `json:"profile_link_color"`
+	ProfileImageUrl                string       `json:"profile_image_url"`
+	Status                         *Tweet       `json:"status"`
+	ProfileUseBackgroundImage      bool         `json:"profile_use_background_image"`
+	FavouritesCount                int64        `json:"favourites_count"`
+	ProfileSidebarFillColor        string       `json:"profile_sidebar_fill_color"`
+	UtcOffset                      int64        `json:"utc_offset"`
+	IsTranslator                   bool         `json:"is_translator"`
+	FollowRequestSent              bool         `json:"follow_request_sent"`
+	Following                      bool         `json:"following"`
+	ProfileBackgroundTile          bool         `json:"profile_background_tile"`
+	ShowAllInlineMedia             bool         `json:"show_all_inline_media"`
+	ProfileTextColor               string       `json:"profile_text_color"`
+	Lang                           string       `json:"lang"`
+	StatusesCount                  int64        `json:"statuses_count"`
+	ContributorsEnabled            bool         `json:"contributors_enabled"`
+	FriendsCount                   int64        `json:"friends_count"`
+	GeoEnabled                     bool         `json:"geo_enabled"`
+	Description                    string       `json:"description"`
+	ProfileSidebarBorderColor      string       `json:"profile_sidebar_border_color"`
+	Entities                       UserEntities `json:"entities"`
+	ProfileImageUrlHttps           string       `json:"profile_image_url_https"`
+	ProfileBackgroundImageUrlHttps string       `json:"profile_background_image_url_https"`
+	ProfileBannerUrl               string       `json:"profile_banner_url"`
+	DefaultProfile                 bool         `json:"default_profile"`
+	DefaultProfileImage            bool         `json:"default_profile_image"`
+	WithheldInCountries            []string     `json:"withheld_in_countries"`
+	WithheldScope                  string       `json:"withheld_scope"`
+	Muting                         bool         `json:"muting"`
+	Blocking                       bool         `json:"blocking"`
+	BlockedBy                      bool         `json:"blocked_by"`
+
+	RawJSON
+}
+
+func (u *User) UnmarshalJSON(data []byte) error {
+	type user User
+	return u.decodeJSON(data, (*user)(u))
+}
+
+func (u User) MarshalJSON() ([]byte, error) {
+	type user User
+	return u.encodeJSON((*user)(&u))
+}
+
+// Entities parsed out of the URL and the description of a user's profile
+type UserEntities struct {
+	Url         UserURLEntities `json:"url"`
+	Description UserURLEntities `json:"description"`
+}
+
+// The t.co links found in a profile field
+type UserURLEntities struct {
+	Urls []URLEntity `json:"urls"`
+}
+
+// Returns the URL of the user's website as entered, rather than its t.co
+// link
+func (u *User) ExpandedUrl() string {
+	for _, e := range u.Entities.Url.Urls {
+		if e.ExpandedUrl != "" {
+			return e.ExpandedUrl
+		}
+	}
+	return u.Url
+}
+
+// Returns the description of the user with its t.co links replaced by the
+// URLs they point to. As with Tweet.DisplayText, the HTML entities Twitter
+// escapes &, < and > with are decoded.
+func (u *User) ExpandedDescription() string {
+	// the indices of the links refer to the unescaped text
+	d := textUnescaper.Replace(u.Description)
+	urls := u.Entities.Description.Urls
+	// replace from the end so that the indices of earlier links hold
+	for i := len(urls) - 1; i >= 0; i-- {
+		e := urls[i]
+		if e.ExpandedUrl == "" || utf16Slice(d, e.Indices.Start(), e.Indices.End()) != e.Url {
+			continue
+		}
+		before := utf16Slice(d, 0, e.Indices.Start())
+		after := d[len(before)+len(e.Url):]
+		d = before + e.ExpandedUrl + after
+	}
+	return d
+}
+
+// Size variants of profile images
+// See https://dev.twitter.com/overview/general/user-profile-images-and-banners
+const (
+	ProfileImageMini     = "mini"    // 24x24
+	ProfileImageNormal   = "normal"  // 48x48
+	ProfileImageBigger   = "bigger"  // 73x73
+	ProfileImage400x400  = "400x400" // 400x400
+	ProfileImageOriginal = ""        // as uploaded
+)
+
+// Returns the URL of the user's profile image in the given size, one of
+// the ProfileImage constants. The HTTPS URL is used when available.
+func (u *User) ProfileImage(size string) string {
+	src := u.ProfileImageUrlHttps
+	if src == "" {
+		src = u.ProfileImageUrl
+	}
+	return ProfileImageSize(src, size)
+}
+
+// Turns the URL of a profile image of any size into the URL of the same
+// image in the given size. URLs that do not name a size are returned as is.
+func ProfileImageSize(imageUrl, size string) string {
+	dir := strings.LastIndex(imageUrl, "/") + 1
+	name := imageUrl[dir:]
+	ext := ""
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name, ext = name[:i], name[i:]
+	}
+	for _, s := range []string{ProfileImageMini, ProfileImageNormal, ProfileImageBigger, ProfileImage400x400} {
+		if strings.HasSuffix(name, "_"+s) {
+			name = strings.TrimSuffix(name, "_"+s)
+			if size != ProfileImageOriginal {
+				name += "_" + size
+			}
+			return imageUrl[:dir] + name + ext
+		}
+	}
+	return imageUrl
+}
+
+// Size variants of profile banners
+// See https://dev.twitter.com/overview/general/user-profile-images-and-banners
+const (
+	BannerWeb          = "web"           // 520x260
+	BannerWebRetina    = "web_retina"    // 1040x520
+	BannerIpad         = "ipad"          // 626x313
+	BannerIpadRetina   = "ipad_retina"   // 1252x626
+	BannerMobile       = "mobile"        // 320x160
+	BannerMobileRetina = "mobile_retina" // 640x320
+	Banner300x100      = "300x100"
+	Banner600x200      = "600x200"
+	Banner1500x500     = "1500x500"
+)
+
+// Returns the URL of the user's profile banner in the given size, one of
+// the Banner constants, or "" if the user has no banner.
+func (u *User) ProfileBanner(size string) string {
+	if u.ProfileBannerUrl == "" {
+		return ""
+	}
+	return strings.TrimSuffix(u.ProfileBannerUrl, "/") + "/" + size
 }
 
 // A list of users
 type UserList []User
 
+// Options of Show, Lookup and Account.VerifyCredentials
+type UserOptions struct {
+	// Whether to include entities
+	IncludeEntities *bool
+	// Leave the last tweet of the user out. Only supported by
+	// VerifyCredentials.
+	SkipStatus bool
+	// Include the email address of the user, if the application is allowed
+	// to. Only supported by VerifyCredentials.
+	IncludeEmail bool
+}
+
+// Returns the optionals to pass to Show, Lookup and VerifyCredentials
+func (uo *UserOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if uo == nil {
+		return opts
+	}
+	opts.setBoolPtr("include_entities", uo.IncludeEntities)
+	opts.setBool("skip_status", uo.SkipStatus)
+	opts.setBool("include_email", uo.IncludeEmail)
+	return opts
+}
+
+// Options of Search
+type UserSearchOptions struct {
+	// Page of results to return
+	Page int
+	// Number of users to return per page, up to 20
+	Count int
+	// Whether to include entities
+	IncludeEntities *bool
+}
+
+// Returns the optionals to pass to Search
+func (so *UserSearchOptions) Optionals() *Optionals {
+	opts := NewOptionals()
+	if so == nil {
+		return opts
+	}
+	opts.setInt("page", so.Page)
+	opts.setInt("count", so.Count)
+	opts.setBoolPtr("include_entities", so.IncludeEntities)
+	return opts
+}
+
 // Provides a simple, relevance-based search interface to public user accounts
 // on Twitter. Try querying by topical interest, full name, company name,
 // location, or other criteria. Exact match searches are not supported.
 // See https://dev.twitter.com/docs/api/1.1/get/users/search
 func (us *UserService) Search(q string, opts *Optionals) (users *UserList, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
-	opts.Add("q", q)
+	opts = opts.Clone()
+	opts.Set("q", q)
 	users = &UserList{}
 	err = us.Call("GET", "users/search", opts, users)
 	return
@@ -77,11 +249,9 @@ func (us *UserService) Search(q string, opts *Optionals) (users *UserList, err e
 
 // See https://dev.twitter.com/docs/api/1.1/get/users/show
 func (us *UserService) Show(screenName string, opts *Optionals) (user *User, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+	opts = opts.Clone()
 	if screenName != "" {
-		opts.Add("screen_name", screenName)
+		opts.Set("screen_name", screenName)
 	}
 	user = &User{}
 	err = us.Call("GET", "users/show", opts, user)
@@ -89,23 +259,14 @@ func (us *UserService) Show(screenName string, opts *Optionals) (user *User, err
 }
 
 // See https://dev.twitter.com/docs/api/1.1/get/users/lookup
-func (us *UserService) Lookup(screenNames []string, userIDs []int64, opts *Optionals) (users *UserList, err error) {
-	if opts == nil {
-		opts = NewOptionals()
-	}
+func (us *UserService) Lookup(screenNames []string, userIDs []ID, opts *Optionals) (users *UserList, err error) {
+	opts = opts.Clone()
 
-	var n int
 	switch {
 	case screenNames != nil && len(screenNames) <= 100:
-		opts.Add("screen_name", strings.Join(screenNames, ","))
-		n = len(screenNames)
+		opts.Set("screen_name", screenNames)
 	case userIDs != nil && len(userIDs) <= 100:
-		n = len(userIDs)
-		ids := make([]string, n)
-		for k, v := range userIDs {
-			ids[k] = strconv.FormatInt(v, 10)
-		}
-		opts.Add("user_id", strings.Join(ids, ","))
+		opts.Set("user_id", userIDs)
 	default:
 		return nil, errors.New("Invalid request.")
 	}
@@ -114,3 +275,11 @@ func (us *UserService) Lookup(screenNames []string, userIDs []int64, opts *Optio
 	err = us.Call("POST", "users/lookup", opts, users)
 	return
 }
+
+// Reports the user specified by screen name or by id as a spammer and
+// blocks them. Pass the "perform_block" optional parameter as false to
+// report without blocking. Returns the reported user.
+// See https://dev.twitter.com/docs/api/1.1/post/users/report_spam
+func (us *UserService) ReportSpam(screenName string, userID ID, opts *Optionals) (user *User, err error) {
+	return us.userAction("users/report_spam", screenName, userID, opts)
+}
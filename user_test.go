@@ -0,0 +1,120 @@
+// tweetlib - A fully oauth-authenticated Go Twitter library
+//
+// Copyright 2011 The Tweetlib Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tweetlib
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExpandedUrl(t *testing.T) {
+	var u User
+	err := json.Unmarshal([]byte(`{
+		"url": "https://t.co/abc",
+		"entities": {"url": {"urls": [{"url": "https://t.co/abc", "expanded_url": "https://golang.org", "indices": [0, 16]}]}}
+	}`), &u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := u.ExpandedUrl(); got != "https://golang.org" {
+		t.Errorf("ExpandedUrl() = %q", got)
+	}
+	if got := (&User{Url: "https://t.co/abc"}).ExpandedUrl(); got != "https://t.co/abc" {
+		t.Errorf("ExpandedUrl() without entities = %q", got)
+	}
+}
+
+func TestExpandedDescription(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{
+			"no links",
+			`{"description": "Gopher"}`,
+			"Gopher",
+		},
+		{
+			"two links",
+			`{"description": "Go https://t.co/a and https://t.co/b!",
+			  "entities": {"description": {"urls": [
+			    {"url": "https://t.co/a", "expanded_url": "https://golang.org", "indices": [3, 17]},
+			    {"url": "https://t.co/b", "expanded_url": "https://go.dev/blog", "indices": [22, 36]}]}}}`,
+			"Go https://golang.org and https://go.dev/blog!",
+		},
+		{
+			"escaped text before a link",
+			`{"description": "Tom &amp; Jerry &lt;3 https://t.co/a",
+			  "entities": {"description": {"urls": [
+			    {"url": "https://t.co/a", "expanded_url": "https://example.com", "indices": [15, 29]}]}}}`,
+			"Tom & Jerry <3 https://example.com",
+		},
+		{
+			"astral characters before a link",
+			`{"description": "😀 https://t.co/a",
+			  "entities": {"description": {"urls": [
+			    {"url": "https://t.co/a", "expanded_url": "https://example.com", "indices": [3, 17]}]}}}`,
+			"😀 https://example.com",
+		},
+		{
+			"indices that do not match the text",
+			`{"description": "Go https://t.co/a",
+			  "entities": {"description": {"urls": [
+			    {"url": "https://t.co/a", "expanded_url": "https://golang.org", "indices": [0, 14]}]}}}`,
+			"Go https://t.co/a",
+		},
+	}
+	for _, tt := range tests {
+		var u User
+		if err := json.Unmarshal([]byte(tt.json), &u); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := u.ExpandedDescription(); got != tt.want {
+			t.Errorf("%s: ExpandedDescription() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestProfileImageSize(t *testing.T) {
+	const base = "https://pbs.twimg.com/profile_images/1/photo"
+	tests := []struct {
+		url, size, want string
+	}{
+		{base + "_normal.jpg", ProfileImageBigger, base + "_bigger.jpg"},
+		{base + "_normal.jpg", ProfileImage400x400, base + "_400x400.jpg"},
+		{base + "_400x400.png", ProfileImageMini, base + "_mini.png"},
+		{base + "_normal.jpg", ProfileImageOriginal, base + ".jpg"},
+		{base + "_bigger", ProfileImageNormal, base + "_normal"},
+		// no size to replace
+		{base + ".jpg", ProfileImageNormal, base + ".jpg"},
+		{"", ProfileImageNormal, ""},
+	}
+	for _, tt := range tests {
+		if got := ProfileImageSize(tt.url, tt.size); got != tt.want {
+			t.Errorf("ProfileImageSize(%q, %q) = %q, want %q", tt.url, tt.size, got, tt.want)
+		}
+	}
+
+	u := &User{
+		ProfileImageUrl:      "http://pbs.twimg.com/profile_images/1/photo_normal.jpg",
+		ProfileImageUrlHttps: base + "_normal.jpg",
+	}
+	if got := u.ProfileImage(ProfileImageBigger); got != base+"_bigger.jpg" {
+		t.Errorf("ProfileImage() = %q", got)
+	}
+}
+
+func TestProfileBanner(t *testing.T) {
+	u := &User{ProfileBannerUrl: "https://pbs.twimg.com/profile_banners/1/2"}
+	if got, want := u.ProfileBanner(Banner1500x500), "https://pbs.twimg.com/profile_banners/1/2/1500x500"; got != want {
+		t.Errorf("ProfileBanner() = %q, want %q", got, want)
+	}
+	if got := (&User{}).ProfileBanner(BannerWeb); got != "" {
+		t.Errorf("ProfileBanner() without banner = %q", got)
+	}
+}